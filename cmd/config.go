@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"ncp-nuke/pkg/config"
+	"ncp-nuke/pkg/excel"
+	"ncp-nuke/pkg/ncp"
+
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "설정 파일 관련 명령",
+}
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "적용되는 설정 출력",
+	Long: `설정 파일에 명령줄 플래그를 적용한 최종 설정을 JSON으로 출력합니다.
+--account 를 지정하면 해당 계정의 groups, accounts 섹션까지 병합한 설정을 출력합니다.
+-f 로 엑셀 파일을 함께 지정하면 계정의 Group 열로 그룹 섹션을 찾습니다.`,
+	Example: "  ncp-nuke config show --config config.json --account my-account -f accounts.xlsx",
+	Args:    cobra.NoArgs,
+	RunE:    runConfigShow,
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate <config>",
+	Short: "설정 파일 검사",
+	Long: `설정 파일(JSON 또는 YAML)을 읽어 알 수 없는 항목, 잘못된 값, 패턴, 조건, 기간을 줄 번호와 함께 알려 줍니다.
+오류가 있으면 종료 코드 2로 끝납니다.`,
+	Example: "  ncp-nuke config validate config.yaml",
+	Args:    cobra.ExactArgs(1),
+	RunE:    runConfigValidate,
+}
+
+var configInitCmd = &cobra.Command{
+	Use:   "init [config.yaml]",
+	Short: "설정 파일 템플릿 생성",
+	Long:  `모든 설정 항목과 리소스 종류별 필터 키를 주석과 함께 담은 YAML 설정 파일(기본: config.yaml)을 생성합니다.`,
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runConfigInit,
+}
+
+func init() {
+	configShowCmd.Flags().StringVar(&configPath, "config", "", "리소스 필터 설정 파일 경로 (JSON 또는 YAML)")
+	configCmd.AddCommand(configShowCmd, configValidateCmd, configInitCmd)
+	rootCmd.AddCommand(configCmd)
+}
+
+func runConfigValidate(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadConfig(args[0])
+	if err != nil {
+		return configError(err)
+	}
+	fmt.Printf("✅ '%s' 설정이 올바릅니다. (필터 %d개, 그룹 섹션 %d개, 계정 섹션 %d개)\n", args[0], len(cfg.Filters), len(cfg.Groups), len(cfg.Accounts))
+	return nil
+}
+
+func runConfigInit(cmd *cobra.Command, args []string) error {
+	filename := "config.yaml"
+	if len(args) > 0 {
+		filename = args[0]
+	}
+	if !config.IsYAML(filename) {
+		return configError(fmt.Errorf("'%s': 템플릿은 YAML 형식입니다. .yaml 또는 .yml 파일명을 지정하세요", filename))
+	}
+	if _, err := os.Stat(filename); err == nil {
+		return configError(fmt.Errorf("'%s' 파일이 이미 존재합니다. 덮어쓰지 않습니다", filename))
+	}
+	if err := os.WriteFile(filename, []byte(config.Template()), 0o644); err != nil {
+		return configError(err)
+	}
+	fmt.Printf("✅ '%s' 파일이 생성되었습니다.\n", filename)
+	return nil
+}
+
+func runConfigShow(cmd *cobra.Command, args []string) error {
+	cfg, err := loadCLIConfig()
+	if err != nil {
+		return configError(err)
+	}
+	if accountFilter != "" {
+		account := ncp.RootAccount{AccountName: accountFilter}
+		if filePath != "" {
+			accounts, err := excel.ReadAccounts(filePath)
+			if err != nil {
+				return configError(err)
+			}
+			found := false
+			for _, a := range accounts {
+				if a.AccountName == accountFilter {
+					account, found = a, true
+					break
+				}
+			}
+			if !found {
+				return configError(fmt.Errorf("엑셀 파일에 '%s' 계정이 없습니다", accountFilter))
+			}
+		}
+		cfg = cfg.ForAccount(account)
+	}
+	if cfg == nil {
+		cfg = &config.Config{}
+	}
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return nil
+}
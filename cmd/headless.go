@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"ncp-nuke/pkg/config"
+	"ncp-nuke/pkg/excel"
+	"ncp-nuke/pkg/journal"
+	"ncp-nuke/pkg/ncp"
+	"ncp-nuke/pkg/progress"
+	"ncp-nuke/pkg/runner"
+
+	"github.com/spf13/cobra"
+)
+
+var headlessAll bool
+var headlessGroup string
+var headlessYes bool
+var headlessConfirm string
+var headlessPassword string
+var headlessCleanup bool
+var listOutput string
+
+var listCmd = newHeadlessCmd("list", "리소스 조회 (비대화형)",
+	`선택한 계정의 리소스를 조회해 출력합니다. 아무것도 변경하지 않습니다.
+--output 을 지정하면 리소스마다 한 줄(계정, 리전, 종류, ID, 이름, 상태, 생성일, VPC, Subnet, 삭제 대상 여부, 필터 규칙)로 출력합니다.`,
+	"  ncp-nuke list -f accounts.xlsx --all --output csv > inventory.csv")
+
+var nukeCmd = newHeadlessCmd("nuke", "리소스 일괄 삭제 (비대화형)",
+	`선택한 계정의 리소스를 필터 설정에 따라 모두 삭제합니다. 서브 계정은 변경하지 않습니다.
+되돌릴 수 없는 작업이므로 --yes 또는 --confirm "CONFIRM DELETE" 가 필요합니다.`,
+	`  ncp-nuke nuke -f accounts.xlsx --group lab --config filters.json --confirm "CONFIRM DELETE"`)
+
+var activateCmd = newHeadlessCmd("activate", "서브 계정 활성화 + 비밀번호 초기화 (비대화형)",
+	`선택한 계정의 서브 계정(IAM Username)을 활성화하고 비밀번호를 초기화합니다.
+엑셀 Password 열이 비어 있는 계정에는 --password 가 적용됩니다.`,
+	"  ncp-nuke activate -f accounts.xlsx --all --password 'Initial123!'")
+
+var deactivateCmd = newHeadlessCmd("deactivate", "서브 계정 비활성화 (비대화형)",
+	`선택한 계정의 서브 계정(IAM Username)을 비활성화합니다.
+--cleanup 을 지정하면 리소스도 삭제하며, 이때는 --yes 또는 --confirm "CONFIRM DELETE" 가 필요합니다.`,
+	"  ncp-nuke deactivate -f accounts.xlsx --account Student-01,Student-02 --cleanup -y")
+
+func init() {
+	for _, c := range []*cobra.Command{listCmd, nukeCmd, activateCmd, deactivateCmd} {
+		c.Flags().StringVar(&configPath, "config", "", "리소스 필터 설정 파일 경로 (JSON 또는 YAML)")
+		c.Flags().BoolVar(&headlessAll, "all", false, "엑셀 파일의 모든 계정 대상")
+		c.Flags().StringVar(&headlessGroup, "group", "", "엑셀 Group 열 기준 대상 계정 (쉼표 구분)")
+		rootCmd.AddCommand(c)
+	}
+	for _, c := range []*cobra.Command{nukeCmd, deactivateCmd} {
+		c.Flags().BoolVarP(&headlessYes, "yes", "y", false, "삭제 작업 확인 (--confirm 대신)")
+		c.Flags().StringVar(&headlessConfirm, "confirm", "", fmt.Sprintf("삭제 확인 문구 (\"%s\")", confirmPhrase))
+	}
+	listCmd.Flags().StringVarP(&listOutput, "output", "o", "", "리소스 목록 출력 형식 (json, yaml, csv, table / 지정 시 진행 로그는 표준 에러로 출력)")
+	activateCmd.Flags().StringVar(&headlessPassword, "password", "", "서브 계정 공통 비밀번호 (엑셀 Password 열이 비어 있는 계정에 적용)")
+	deactivateCmd.Flags().BoolVar(&headlessCleanup, "cleanup", false, "비활성화와 함께 리소스 삭제")
+}
+
+// newHeadlessCmd builds a non-interactive command running action. Errors
+// before anything runs exit with code 2, a run with failures with code 1.
+func newHeadlessCmd(action, short, long, example string) *cobra.Command {
+	return &cobra.Command{
+		Use:     action,
+		Short:   short,
+		Long:    long + "\n\n대상 계정은 --account (쉼표 구분), --group 또는 --all 로 지정합니다.\n종료 코드: 0 모두 성공, 1 일부 실패 또는 잔여 리소스, 2 설정 오류",
+		Example: example,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runHeadless(action)
+		},
+	}
+}
+
+func runHeadless(action string) error {
+	if action == "list" && listOutput != "" && !validOutputFormat(listOutput) {
+		return configError(fmt.Errorf("지원하지 않는 출력 형식: %s (json, yaml, csv, table)", listOutput))
+	}
+	cfg, err := loadCLIConfig()
+	if err != nil {
+		return configError(err)
+	}
+	accounts, err := selectHeadlessAccounts()
+	if err != nil {
+		return configError(err)
+	}
+	cleanup := action == "deactivate" && headlessCleanup
+	if action == "nuke" || cleanup {
+		if headlessConfirm != "" && headlessConfirm != confirmPhrase {
+			return configError(fmt.Errorf("삭제 확인 문구가 일치하지 않습니다. --confirm \"%s\" 를 정확히 입력하세요", confirmPhrase))
+		}
+		if !headlessYes && headlessConfirm == "" {
+			return configError(fmt.Errorf("삭제 작업에는 --yes 또는 --confirm \"%s\" 가 필요합니다", confirmPhrase))
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if config.Guarded(cfg) {
+		if err := checkAccountGuard(ctx, accounts, cfg); err != nil {
+			return configError(err)
+		}
+	}
+
+	var j *journal.Writer
+	if action != "list" {
+		if j, err = openCLIJournal(cfg); err != nil {
+			return configError(err)
+		}
+		defer j.Close()
+	}
+
+	selected := make(map[int]bool, len(accounts))
+	for i := range accounts {
+		selected[i] = true
+	}
+	// With --output stdout carries only the resource list.
+	logOut := os.Stdout
+	if action == "list" && listOutput != "" {
+		logOut = os.Stderr
+	}
+	sink := progress.TextSink(func(s string) { fmt.Fprintln(logOut, s) })
+	res := runner.Process(ctx, accounts, selected, action, headlessPassword, cleanup, cfg, j, sink)
+	if action == "list" && listOutput != "" {
+		if err := writeResources(os.Stdout, listOutput, res.Resources); err != nil {
+			return err
+		}
+	}
+	if !res.Clean() {
+		return &exitError{code: exitPartial, err: resultError(res)}
+	}
+	return nil
+}
+
+// checkAccountGuard resolves the member number of every account and refuses
+// the whole run when the account guard of cfg does not allow one of them.
+func checkAccountGuard(ctx context.Context, accounts []ncp.RootAccount, cfg *config.Config) error {
+	runner.ResolveIdentities(ctx, accounts, cfg)
+	var refused []string
+	for _, a := range accounts {
+		if err := config.CheckAccount(cfg, a.MemberNo); err != nil {
+			refused = append(refused, fmt.Sprintf("%s: %v", a.AccountName, err))
+		}
+	}
+	if len(refused) > 0 {
+		return fmt.Errorf("account_guard 가 허용하지 않는 계정이 있어 실행하지 않습니다\n  %s", strings.Join(refused, "\n  "))
+	}
+	return nil
+}
+
+// resultError describes why a run did not finish cleanly.
+func resultError(res runner.Result) error {
+	if res.Cancelled {
+		return fmt.Errorf("작업이 취소되었습니다")
+	}
+	var parts []string
+	if res.Fail > 0 {
+		parts = append(parts, fmt.Sprintf("서브 계정 작업 실패 %d", res.Fail))
+	}
+	if res.CleanupFail > 0 {
+		parts = append(parts, fmt.Sprintf("리소스 삭제 실패 %d", res.CleanupFail))
+	}
+	if len(res.Dirty) > 0 {
+		parts = append(parts, "잔여 리소스: "+strings.Join(res.Dirty, ", "))
+	}
+	if len(res.Failed) > 0 {
+		parts = append(parts, "오류 계정: "+strings.Join(res.Failed, ", "))
+	}
+	return fmt.Errorf("일부 작업이 실패했습니다 (%s)", strings.Join(parts, ", "))
+}
+
+// selectHeadlessAccounts reads the -f Excel file and returns the accounts
+// named by --account, in a --group, or all of them with --all. A headless
+// run never defaults to every account, and unknown names or groups are
+// errors rather than silently matching nothing.
+func selectHeadlessAccounts() ([]ncp.RootAccount, error) {
+	if filePath == "" {
+		return nil, fmt.Errorf("엑셀 파일 경로가 지정되지 않았습니다. -f 또는 --file 플래그를 사용하세요")
+	}
+	names, groups := splitList(accountFilter), splitList(headlessGroup)
+	switch {
+	case headlessAll && (len(names) > 0 || len(groups) > 0):
+		return nil, fmt.Errorf("--all 은 --account, --group 과 함께 사용할 수 없습니다")
+	case !headlessAll && len(names) == 0 && len(groups) == 0:
+		return nil, fmt.Errorf("대상 계정을 지정하세요 (--account, --group 또는 --all)")
+	}
+	accounts, err := excel.ReadAccounts(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if headlessAll {
+		return accounts, nil
+	}
+
+	wantName := make(map[string]bool, len(names))
+	for _, n := range names {
+		wantName[n] = false
+	}
+	wantGroup := make(map[string]bool, len(groups))
+	for _, g := range groups {
+		wantGroup[strings.ToLower(g)] = false
+	}
+	var out []ncp.RootAccount
+	for _, a := range accounts {
+		_, byName := wantName[a.AccountName]
+		_, byGroup := wantGroup[strings.ToLower(a.Group)]
+		byGroup = byGroup && a.Group != ""
+		if byName {
+			wantName[a.AccountName] = true
+		}
+		if byGroup {
+			wantGroup[strings.ToLower(a.Group)] = true
+		}
+		if byName || byGroup {
+			out = append(out, a)
+		}
+	}
+	var missing []string
+	for _, n := range names {
+		if !wantName[n] {
+			missing = append(missing, "계정 "+n)
+		}
+	}
+	for _, g := range groups {
+		if !wantGroup[strings.ToLower(g)] {
+			missing = append(missing, "그룹 "+g)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("엑셀 파일에 없는 대상: %s", strings.Join(missing, ", "))
+	}
+	return out, nil
+}
+
+// splitList splits a comma-separated flag value, dropping empty entries.
+func splitList(s string) []string {
+	var out []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"ncp-nuke/pkg/runner"
+
+	"gopkg.in/yaml.v3"
+)
+
+// outputFormats are the --output values of the list command.
+var outputFormats = []string{"json", "yaml", "csv", "table"}
+
+func validOutputFormat(format string) bool {
+	for _, f := range outputFormats {
+		if strings.EqualFold(format, f) {
+			return true
+		}
+	}
+	return false
+}
+
+// resourceColumns are the csv / table columns, in order.
+var resourceColumns = []string{"account", "region", "type", "id", "name", "status", "created", "vpcNo", "subnetNo", "kept", "rule"}
+
+func resourceRow(r runner.ResourceRecord) []string {
+	return []string{r.Account, r.Region, r.Type, r.ID, r.Name, r.Status, r.Created, r.VpcNo, r.SubnetNo, strconv.FormatBool(r.Kept), r.Rule}
+}
+
+// writeResources writes records to w in format, one record per resource.
+func writeResources(w io.Writer, format string, records []runner.ResourceRecord) error {
+	if records == nil {
+		records = []runner.ResourceRecord{} // an empty list, not null
+	}
+	switch strings.ToLower(format) {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(records)
+	case "yaml":
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(records); err != nil {
+			return err
+		}
+		return enc.Close()
+	case "csv":
+		cw := csv.NewWriter(w)
+		if err := cw.Write(resourceColumns); err != nil {
+			return err
+		}
+		for _, r := range records {
+			if err := cw.Write(resourceRow(r)); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	case "table":
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.ToUpper(strings.Join(resourceColumns, "\t")))
+		for _, r := range records {
+			row := resourceRow(r)
+			for i, v := range row {
+				if v == "" {
+					row[i] = "-"
+				}
+			}
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+		return tw.Flush()
+	}
+	return fmt.Errorf("지원하지 않는 출력 형식: %s", format)
+}
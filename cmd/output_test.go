@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"ncp-nuke/pkg/runner"
+)
+
+// failingWriter accepts n bytes, then fails every write.
+type failingWriter struct{ n int }
+
+var errWrite = errors.New("disk full")
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if len(p) > w.n {
+		n := w.n
+		w.n = 0
+		return n, errWrite
+	}
+	w.n -= len(p)
+	return len(p), nil
+}
+
+func TestWriteResources(t *testing.T) {
+	records := []runner.ResourceRecord{
+		{Account: "Student-01", Region: "KR", Type: "login_keys", Name: "key", Kept: true},
+		{Account: "Student-01", Region: "KR", Type: "servers", ID: "100", Name: "web, prod", Rule: "exclude: glob:web*"},
+	}
+	var buf bytes.Buffer
+	if err := writeResources(&buf, "csv", records); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 || lines[2] != `Student-01,KR,servers,100,"web, prod",,,,,false,exclude: glob:web*` {
+		t.Errorf("csv:\n%s", buf.String())
+	}
+
+	for _, format := range []string{"json", "yaml", "csv", "table"} {
+		if err := writeResources(&failingWriter{n: 10}, format, records); err == nil {
+			t.Errorf("%s: want the write error", format)
+		}
+	}
+	// Rows bigger than the csv buffer fail in Write, before any Flush.
+	big := []runner.ResourceRecord{{Name: strings.Repeat("x", 8192)}, {Name: "after"}}
+	if err := writeResources(&failingWriter{n: 10}, "csv", big); !errors.Is(err, errWrite) {
+		t.Errorf("csv, large rows: err = %v, want the write error", err)
+	}
+}
@@ -0,0 +1,227 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"ncp-nuke/pkg/config"
+	"ncp-nuke/pkg/excel"
+	"ncp-nuke/pkg/journal"
+	"ncp-nuke/pkg/ncp"
+	"ncp-nuke/pkg/plan"
+	"ncp-nuke/pkg/progress"
+	"ncp-nuke/pkg/runner"
+
+	"github.com/spf13/cobra"
+)
+
+const confirmPhrase = "CONFIRM DELETE"
+
+var planOutput string
+var planMaxAge time.Duration
+var planYes bool
+
+var planCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "삭제 계획 파일 생성 (조회만 수행)",
+	Long: `계정들의 리소스를 조회해 삭제 대상(계정, 리전, 종류, ID, 이름, 삭제 순서)을 계획 파일(JSON)로 저장합니다.
+저장된 계획은 apply 명령 또는 웹 UI에서 검토 후 실행할 수 있으며, 계획에 없는 리소스는 삭제되지 않습니다.`,
+	Example: "  ncp-nuke plan -f accounts.xlsx -o plan.json",
+	RunE:    runPlan,
+}
+
+var applyCmd = &cobra.Command{
+	Use:     "apply <plan.json>",
+	Short:   "삭제 계획 파일 실행",
+	Long:    `plan 명령으로 만든 계획 파일의 리소스만 삭제합니다. 계획이 너무 오래되었거나 계정의 인증 키가 바뀌었으면 실행하지 않습니다.`,
+	Example: "  ncp-nuke apply plan.json -f accounts.xlsx",
+	Args:    cobra.ExactArgs(1),
+	RunE:    runApply,
+}
+
+func init() {
+	planCmd.Flags().StringVarP(&planOutput, "output", "o", "plan.json", "계획 파일 저장 경로")
+	planCmd.Flags().StringVar(&configPath, "config", "", "리소스 필터 설정 파일 경로 (JSON 또는 YAML)")
+	applyCmd.Flags().DurationVar(&planMaxAge, "max-age", plan.DefaultMaxAge, "허용하는 계획 파일의 최대 경과 시간 (0: 제한 없음)")
+	applyCmd.Flags().BoolVarP(&planYes, "yes", "y", false, "삭제 확인 문구 입력 생략")
+	applyCmd.Flags().StringVar(&configPath, "config", "", "설정 파일 경로 (JSON 또는 YAML, 필터는 무시되고 계획의 리소스만 삭제)")
+	rootCmd.AddCommand(planCmd, applyCmd)
+}
+
+// loadCLIConfig loads --config (if any) and applies the global flags to it.
+func loadCLIConfig() (*config.Config, error) {
+	if err := validateSiteFlags(); err != nil {
+		return nil, err
+	}
+	var cfg *config.Config
+	if configPath != "" {
+		c, err := config.LoadConfig(configPath)
+		if err != nil {
+			return nil, err
+		}
+		cfg = c
+	}
+	cfg = cliOverrides().Apply(cfg)
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// loadCLIAccounts reads the -f Excel file, narrowed to --account if given.
+func loadCLIAccounts() ([]ncp.RootAccount, error) {
+	if filePath == "" {
+		return nil, fmt.Errorf("엑셀 파일 경로가 지정되지 않았습니다. -f 또는 --file 플래그를 사용하세요")
+	}
+	accounts, err := excel.ReadAccounts(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if accountFilter != "" {
+		var filtered []ncp.RootAccount
+		for _, a := range accounts {
+			if a.AccountName == accountFilter {
+				filtered = append(filtered, a)
+			}
+		}
+		accounts = filtered
+	}
+	if len(accounts) == 0 {
+		return nil, fmt.Errorf("대상 계정이 없습니다")
+	}
+	return accounts, nil
+}
+
+// openCLIJournal starts the operation journal of a destructive command and
+// tells the user where it is and how to resume from it.
+func openCLIJournal(cfg *config.Config) (*journal.Writer, error) {
+	j, err := runner.NewJournal(cfg)
+	if err != nil || j == nil {
+		return j, err
+	}
+	fmt.Printf("작업 저널: %s (중단되면 ncp-nuke resume %s -f %s 로 재개)\n", j.Path(), j.Path(), filePath)
+	return j, nil
+}
+
+func runPlan(cmd *cobra.Command, args []string) error {
+	cfg, err := loadCLIConfig()
+	if err != nil {
+		return err
+	}
+	accounts, err := loadCLIAccounts()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	fmt.Printf("계정 %d개의 리소스 조회 중...\n", len(accounts))
+	p, errs := plan.Build(ctx, accounts, cfg)
+	if ctx.Err() != nil {
+		return fmt.Errorf("취소되었습니다")
+	}
+	for _, e := range errs {
+		fmt.Printf("  [경고] %v\n", e)
+	}
+	for _, a := range p.Accounts {
+		fmt.Printf("\n[루트 계정: %s] 삭제 대상 %d개\n", a.Name, len(a.Resources))
+		for _, r := range a.Resources {
+			rt, _ := ncp.LookupResourceType(r.Type)
+			line := fmt.Sprintf("  %3d. [%s] %s: %s", r.Order+1, r.Region, rt.Name(), r.Name)
+			if r.ID != "" && r.ID != r.Name {
+				line += fmt.Sprintf(" (%s)", r.ID)
+			}
+			fmt.Println(line)
+		}
+	}
+	if names := untaggedTypes(p); len(names) > 0 {
+		fmt.Printf("\n[참고] 태그를 지원하지 않아 keep 태그로 보호할 수 없는 종류: %s\n", strings.Join(names, ", "))
+	}
+	if err := p.Save(planOutput); err != nil {
+		return err
+	}
+	fmt.Printf("\n✅ 삭제 계획 저장: %s (리소스 %d개)\n", planOutput, p.Count())
+	fmt.Printf("   실행: ncp-nuke apply %s -f %s\n", planOutput, filePath)
+	return nil
+}
+
+// untaggedTypes returns the names of the types in p that have no tags (see
+// ncp.ResourceType.Tagged), in deletion order.
+func untaggedTypes(p *plan.Plan) []string {
+	seen := make(map[string]bool)
+	for _, a := range p.Accounts {
+		for _, r := range a.Resources {
+			seen[r.Type] = true
+		}
+	}
+	var names []string
+	for _, rt := range ncp.DeletionOrder() {
+		if seen[rt.Key()] && !rt.Tagged() {
+			names = append(names, rt.Name())
+		}
+	}
+	return names
+}
+
+func runApply(cmd *cobra.Command, args []string) error {
+	p, err := plan.Load(args[0])
+	if err != nil {
+		return err
+	}
+	cfg, err := loadCLIConfig()
+	if err != nil {
+		return err
+	}
+	all, err := loadCLIAccounts()
+	if err != nil {
+		return err
+	}
+	accounts, err := p.Check(all, planMaxAge)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("계획 파일: %s (생성: %s, 계정 %d개, 리소스 %d개)\n",
+		args[0], p.CreatedAt.Local().Format("2006-01-02 15:04"), len(accounts), p.Count())
+	if p.Count() == 0 {
+		fmt.Println("삭제할 리소스가 없습니다.")
+		return nil
+	}
+	if !planYes {
+		fmt.Printf("되돌릴 수 없는 작업입니다. 계속하려면 \"%s\" 를 입력하세요: ", confirmPhrase)
+		line, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		if strings.TrimSpace(line) != confirmPhrase {
+			return fmt.Errorf("삭제 확인 문구가 일치하지 않아 중단합니다")
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	j, err := openCLIJournal(cfg)
+	if err != nil {
+		return err
+	}
+	defer j.Close()
+
+	sink := progress.TextSink(func(s string) { fmt.Println(s) })
+	var res runner.Result
+	for _, acc := range accounts {
+		pa, _ := p.Account(acc.AccountName)
+		res.Add(runner.Process(ctx, []ncp.RootAccount{acc}, map[int]bool{0: true}, "nuke", "", false, pa.Config(cfg.ForAccount(acc)), j, sink))
+		if ctx.Err() != nil {
+			res.Cancelled = true
+			break
+		}
+	}
+	if !res.Clean() {
+		return &exitError{code: exitPartial, err: resultError(res)}
+	}
+	return nil
+}
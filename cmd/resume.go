@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"ncp-nuke/pkg/config"
+	"ncp-nuke/pkg/journal"
+	"ncp-nuke/pkg/ncp"
+	"ncp-nuke/pkg/progress"
+	"ncp-nuke/pkg/runner"
+
+	"github.com/spf13/cobra"
+)
+
+var resumePassword string
+var resumeYes bool
+
+var resumeCmd = &cobra.Command{
+	Use:   "resume <journal>",
+	Short: "중단된 작업을 작업 저널에서 재개",
+	Long: `중단된 실행(Ctrl+C, 프로세스 종료, 네트워크 단절 등)이 남긴 작업 저널을 읽어 끝나지 않은 계정의 작업을 이어서 수행합니다.
+이미 완료된 작업은 건너뛰고, 중단 시점에 진행 중이던 삭제는 리소스가 남아 있는지 다시 확인한 뒤 필요한 것만 재시도합니다.
+원래 실행의 작업 종류, 리소스 삭제 여부와 설정(필터, 리전 등)을 그대로 사용합니다.`,
+	Example: "  ncp-nuke resume ncp-nuke-20240101-120000.journal -f accounts.xlsx",
+	Args:    cobra.ExactArgs(1),
+	RunE:    runResume,
+}
+
+func init() {
+	resumeCmd.Flags().StringVar(&resumePassword, "password", "", "서브 계정 공통 비밀번호 (원래 실행에서 공통 비밀번호로 활성화한 경우 필수)")
+	resumeCmd.Flags().BoolVarP(&resumeYes, "yes", "y", false, "삭제 확인 문구 입력 생략")
+	rootCmd.AddCommand(resumeCmd)
+}
+
+// resumeRun is an unfinished run of a journal with what it needs to go on.
+type resumeRun struct {
+	run     *journal.Run
+	account ncp.RootAccount
+	cfg     *config.Config
+}
+
+func runResume(cmd *cobra.Command, args []string) error {
+	if err := validateSiteFlags(); err != nil {
+		return err
+	}
+	j, records, err := journal.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer j.Close()
+	var pending []*journal.Run
+	for _, run := range journal.Runs(records) {
+		if !run.Done {
+			pending = append(pending, run)
+		}
+	}
+	if len(pending) == 0 {
+		fmt.Println("모든 작업이 완료된 저널입니다. 재개할 작업이 없습니다.")
+		return nil
+	}
+
+	all, err := loadCLIAccounts()
+	if err != nil {
+		return err
+	}
+	runs, err := matchResumeRuns(pending, all)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("작업 저널: %s (미완료 작업 %d개)\n", args[0], len(runs))
+	destructive := false
+	for _, r := range runs {
+		start := r.run.Start
+		line := fmt.Sprintf("  - %s: %s", start.Account, start.Action)
+		if start.Cleanup {
+			line += " + 리소스 삭제"
+		}
+		if n := len(r.run.InFlight); n > 0 {
+			line += fmt.Sprintf(" (진행 중이던 작업 %d개 확인 필요)", n)
+		}
+		fmt.Println(line)
+		destructive = destructive || start.Action == "nuke" || start.Cleanup
+	}
+	if destructive && !resumeYes {
+		fmt.Printf("되돌릴 수 없는 작업입니다. 계속하려면 \"%s\" 를 입력하세요: ", confirmPhrase)
+		line, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		if strings.TrimSpace(line) != confirmPhrase {
+			return fmt.Errorf("삭제 확인 문구가 일치하지 않아 중단합니다")
+		}
+	}
+
+	if err := j.Append(journal.Record{Kind: journal.KindResume}); err != nil {
+		return fmt.Errorf("작업 저널 기록 실패: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	sink := progress.TextSink(func(s string) { fmt.Println(s) })
+	var res runner.Result
+	for _, r := range runs {
+		start := r.run.Start
+		progress.NewLogger(sink).Phase(progress.PhaseAccount).Account(start.Account).Info(0, "[재개: %s]", start.Account)
+		runner.VerifyInFlight(ctx, r.account, r.cfg, r.run.InFlight, j, sink)
+		if ctx.Err() != nil {
+			res.Cancelled = true
+			break
+		}
+		res.Add(runner.Process(ctx, []ncp.RootAccount{r.account}, map[int]bool{0: true}, start.Action, resumePassword, start.Cleanup, r.cfg, j, sink))
+		if ctx.Err() != nil {
+			res.Cancelled = true
+			break
+		}
+	}
+	if !res.Clean() {
+		return &exitError{code: exitPartial, err: resultError(res)}
+	}
+	return nil
+}
+
+// matchResumeRuns pairs each unfinished run with its account (same name and
+// access key pair) and recorded config, and checks that a run which used a
+// common password gets one again.
+func matchResumeRuns(pending []*journal.Run, accounts []ncp.RootAccount) ([]resumeRun, error) {
+	byName := make(map[string]ncp.RootAccount, len(accounts))
+	for _, a := range accounts {
+		byName[a.AccountName] = a
+	}
+	var runs []resumeRun
+	var problems []string
+	for _, run := range pending {
+		start := run.Start
+		acc, ok := byName[start.Account]
+		switch {
+		case !ok:
+			problems = append(problems, fmt.Sprintf("%s: 계정 목록에 없음", start.Account))
+			continue
+		case acc.Fingerprint() != start.Fingerprint:
+			problems = append(problems, fmt.Sprintf("%s: 인증 키가 원래 실행과 다름", start.Account))
+			continue
+		case start.Password && resumePassword == "":
+			problems = append(problems, fmt.Sprintf("%s: 원래 실행에서 공통 비밀번호를 사용했습니다. --password 를 지정하세요", start.Account))
+			continue
+		}
+		var cfg *config.Config
+		if len(start.Config) > 0 {
+			cfg = &config.Config{}
+			if err := json.Unmarshal(start.Config, cfg); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: 기록된 설정 해석 실패: %v", start.Account, err))
+				continue
+			}
+		}
+		cfg = config.Overrides{Workers: workersFlag, MaxPasses: maxPassesFlag}.Apply(cfg)
+		runs = append(runs, resumeRun{run: run, account: acc, cfg: cfg})
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("작업을 재개할 수 없습니다: %s", strings.Join(problems, ", "))
+	}
+	return runs, nil
+}
@@ -1,9 +1,13 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
+	"ncp-nuke/pkg/config"
+	"ncp-nuke/pkg/ncp"
+	"ncp-nuke/pkg/runner"
 	"ncp-nuke/pkg/tui"
 
 	"github.com/spf13/cobra"
@@ -12,6 +16,15 @@ import (
 var filePath string
 var accountFilter string
 var configPath string
+var regionFlag string
+var siteFlag string
+var platformFlag string
+var workersFlag int
+var maxPassesFlag int
+var journalDirFlag string
+var olderThanFlag string
+var newerThanFlag string
+var vpcFlag string
 
 var rootCmd = &cobra.Command{
 	Use:   "ncp-nuke",
@@ -21,24 +34,126 @@ var rootCmd = &cobra.Command{
 
 엑셀 파일에 루트 계정 정보(AccountName, AccessKey, SecretKey)를 입력하고,
 각 루트 계정의 서브 계정들을 일괄 관리합니다.`,
+	// Execute prints errors, once, for every command.
+	SilenceUsage:  true,
+	SilenceErrors: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if filePath == "" {
 			return fmt.Errorf("엑셀 파일 경로가 지정되지 않았습니다. -f 또는 --file 플래그를 사용하세요")
 		}
-		return tui.Start(filePath, configPath, accountFilter)
+		if err := validateSiteFlags(); err != nil {
+			return err
+		}
+		return tui.Start(filePath, configPath, accountFilter, cliOverrides())
 	},
 }
 
+// validateSiteFlags rejects unknown --site / --platform values, a negative
+// --workers / --max-passes and bad --older-than / --newer-than up front.
+func validateSiteFlags() error {
+	if _, err := ncp.ParseSite(siteFlag); err != nil {
+		return err
+	}
+	if _, err := ncp.ParsePlatform(platformFlag); err != nil {
+		return err
+	}
+	if workersFlag < 0 {
+		return fmt.Errorf("--workers 값은 1 이상이어야 합니다: %d", workersFlag)
+	}
+	if maxPassesFlag < 0 {
+		return fmt.Errorf("--max-passes 값은 1 이상이어야 합니다: %d", maxPassesFlag)
+	}
+	_, _, err := parseAgeFlags()
+	return err
+}
+
+// cliOverrides returns the settings given by the global flags. Call it after
+// validateSiteFlags.
+func cliOverrides() config.Overrides {
+	older, newer, _ := parseAgeFlags()
+	return config.Overrides{
+		Regions:    config.ParseRegions(regionFlag),
+		Site:       siteFlag,
+		Platform:   platformFlag,
+		Workers:    workersFlag,
+		MaxPasses:  maxPassesFlag,
+		JournalDir: journalDirFlag,
+		OlderThan:  older,
+		NewerThan:  newer,
+		VpcScope:   splitList(vpcFlag),
+	}
+}
+
+// parseAgeFlags parses --older-than and --newer-than (zero when unset).
+func parseAgeFlags() (older, newer config.Duration, err error) {
+	if olderThanFlag != "" {
+		if older, err = config.ParseDuration(olderThanFlag); err != nil {
+			return 0, 0, fmt.Errorf("--older-than: %w", err)
+		}
+	}
+	if newerThanFlag != "" {
+		if newer, err = config.ParseDuration(newerThanFlag); err != nil {
+			return 0, 0, fmt.Errorf("--newer-than: %w", err)
+		}
+	}
+	age := config.ResourceFilter{OlderThan: older, NewerThan: newer}
+	if err := age.Validate(); err != nil {
+		return 0, 0, err
+	}
+	return older, newer, nil
+}
+
+// Exit codes of the headless commands.
+const (
+	exitPartial = 1 // the run finished with failures or leftovers
+	exitConfig  = 2 // bad flags, config or account file; nothing was run
+)
+
+// exitError carries the process exit code for err.
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string { return e.err.Error() }
+func (e *exitError) Unwrap() error { return e.err }
+
+// configError marks err as a configuration error (exit code 2).
+func configError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &exitError{code: exitConfig, err: err}
+}
+
+// Execute runs the command line and exits with the code of its error.
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		code := 1
+		var ee *exitError
+		if errors.As(err, &ee) {
+			code = ee.code
+		}
+		os.Exit(code)
 	}
 }
 
 func init() {
+	// Bad flags of any command are configuration errors.
+	rootCmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return configError(err)
+	})
 	rootCmd.PersistentFlags().StringVarP(&filePath, "file", "f", "", "루트 계정 목록 엑셀 파일 경로 (필수)")
 	rootCmd.PersistentFlags().StringVarP(&accountFilter, "account", "a", "", "특정 루트 계정만 대상 (AccountName 기준)")
-	rootCmd.Flags().StringVar(&configPath, "config", "", "리소스 필터 설정 파일 경로 (JSON)")
+	rootCmd.PersistentFlags().StringVar(&regionFlag, "region", "", "대상 리전 (쉼표 구분, 예: KR,SGN / all: 전체 리전)")
+	rootCmd.PersistentFlags().StringVar(&siteFlag, "site", "", "기본 클라우드 사이트 (public, fin, gov / 엑셀 Site 열이 비어 있는 계정에 적용)")
+	rootCmd.PersistentFlags().StringVar(&platformFlag, "platform", "", "기본 플랫폼 (auto, vpc, classic, all / 엑셀 Platform 열이 비어 있는 계정에 적용)")
+	rootCmd.PersistentFlags().IntVar(&workersFlag, "workers", 0, fmt.Sprintf("계정·리전별 동시 삭제 수 (기본 %d)", ncp.DefaultWorkers))
+	rootCmd.PersistentFlags().IntVar(&maxPassesFlag, "max-passes", 0, fmt.Sprintf("리전별 최대 삭제 패스 수 (남은 리소스 재조회 후 재시도, 기본 %d)", runner.DefaultMaxPasses))
+	rootCmd.PersistentFlags().StringVar(&journalDirFlag, "journal-dir", "", "작업 저널 저장 디렉터리 (기본: 현재 디렉터리, -: 저널 기록 안 함)")
+	rootCmd.PersistentFlags().StringVar(&olderThanFlag, "older-than", "", "생성 후 지정 기간이 지난 리소스만 대상 (예: 72h, 7d)")
+	rootCmd.PersistentFlags().StringVar(&vpcFlag, "vpc", "", "지정한 VPC(이름 또는 번호, 쉼표 구분)와 그 안의 리소스만 대상")
+	rootCmd.PersistentFlags().StringVar(&newerThanFlag, "newer-than", "", "생성 후 지정 기간이 지나지 않은 리소스만 대상 (예: 24h)")
+	rootCmd.Flags().StringVar(&configPath, "config", "", "리소스 필터 설정 파일 경로 (JSON 또는 YAML)")
 }
-
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// run executes the command line args and returns its exit code and what
+// cobra itself printed.
+func run(t *testing.T, args ...string) (int, string) {
+	t.Helper()
+	var out bytes.Buffer
+	rootCmd.SetOut(&out)
+	rootCmd.SetErr(&out)
+	rootCmd.SetArgs(args)
+	t.Cleanup(func() {
+		rootCmd.SetOut(nil)
+		rootCmd.SetErr(nil)
+		rootCmd.SetArgs(nil)
+	})
+	err := rootCmd.Execute()
+	if err == nil {
+		return 0, out.String()
+	}
+	var ee *exitError
+	if errors.As(err, &ee) {
+		return ee.code, out.String()
+	}
+	return 1, out.String()
+}
+
+func TestExitCodes(t *testing.T) {
+	existing := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(existing, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for name, args := range map[string][]string{
+		"unknown flag":          {"list", "--no-such-flag"},
+		"config init, exists":   {"config", "init", existing},
+		"config init, not yaml": {"config", "init", filepath.Join(t.TempDir(), "config.json")},
+	} {
+		code, out := run(t, args...)
+		if code != exitConfig {
+			t.Errorf("%s: exit code %d, want %d", name, code, exitConfig)
+		}
+		// Execute prints the error; cobra prints nothing of its own.
+		if out != "" {
+			t.Errorf("%s: cobra printed %q", name, out)
+		}
+	}
+}
@@ -18,11 +18,15 @@ var serveCmd = &cobra.Command{
 엑셀 파일의 루트 계정 목록을 불러와 계정 선택/활성화/비활성화/리소스 삭제/조회를
 브라우저에서 수행할 수 있습니다.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateSiteFlags(); err != nil {
+			return err
+		}
 		// -f 는 선택사항입니다. 미지정 시 브라우저에서 엑셀을 업로드해 계정을 불러옵니다.
 		srv, err := web.NewServer(filePath, configPath)
 		if err != nil {
 			return err
 		}
+		srv.SetOverrides(cliOverrides())
 		addr := fmt.Sprintf("127.0.0.1:%d", servePort)
 		fmt.Printf("🧨 NCP Nuke 웹 콘솔 실행 중: http://%s\n", addr)
 		fmt.Println("종료하려면 Ctrl+C 를 누르세요.")
@@ -31,7 +35,7 @@ var serveCmd = &cobra.Command{
 }
 
 func init() {
-	serveCmd.Flags().StringVar(&configPath, "config", "", "리소스 필터 설정 파일 경로 (JSON)")
+	serveCmd.Flags().StringVar(&configPath, "config", "", "리소스 필터 설정 파일 경로 (JSON 또는 YAML)")
 	serveCmd.Flags().IntVarP(&servePort, "port", "p", 8080, "웹 서버 포트")
 	rootCmd.AddCommand(serveCmd)
 }
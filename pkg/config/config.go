@@ -1,14 +1,40 @@
 package config
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"ncp-nuke/pkg/ncp"
 )
 
 type ResourceFilter struct {
 	Enabled *bool    `json:"enabled,omitempty"`
 	Include []string `json:"include"`
 	Exclude []string `json:"exclude"`
+	// Where holds property conditions a resource must all meet (see
+	// Condition), on top of Include / Exclude.
+	Where []Condition `json:"where,omitempty"`
+
+	// OlderThan and NewerThan keep only resources created more / less than
+	// that long ago. A resource whose creation date is unknown is not kept
+	// when either is set.
+	OlderThan Duration `json:"older_than,omitempty"`
+	NewerThan Duration `json:"newer_than,omitempty"`
+
+	// IncludeTags and ExcludeTags select resources by tag, like Include /
+	// Exclude by name: entries are "key" (any value) or "key=value", where
+	// value may be a pattern (see MatchPattern).
+	IncludeTags []string `json:"include_tags,omitempty"`
+	ExcludeTags []string `json:"exclude_tags,omitempty"`
 }
 
 // IsEnabled returns whether this resource filter is enabled.
@@ -19,8 +45,10 @@ func (f *ResourceFilter) IsEnabled() bool {
 
 // Match checks if a resource name or ID matches the filter.
 // Rules:
-// 1. If Include is not empty, the resource MUST be in the Include list to be considered.
-// 2. If Exclude is not empty, the resource MUST NOT be in the Exclude list.
+// 1. If Include is not empty, the resource MUST match an Include entry to be considered.
+// 2. If Exclude is not empty, the resource MUST NOT match any Exclude entry.
+// Entries are patterns (see MatchPattern). Where conditions need the whole
+// resource and are checked by Evaluate only.
 func (f *ResourceFilter) Match(name, id string) bool {
 	if !f.IsEnabled() {
 		return false
@@ -28,72 +56,1054 @@ func (f *ResourceFilter) Match(name, id string) bool {
 
 	// If Include list is provided, default to false (reject), unless matched.
 	// If Include list is empty, default to true (allow all).
-	allowed := true
+	if len(f.Include) > 0 && !matchAny(f.Include, name, id) {
+		return false
+	}
+
+	// Check Exclude list
+	return !matchAny(f.Exclude, name, id)
+}
+
+// Evaluate decides whether the filter keeps it and names the rule that
+// decided, in config syntax (e.g. "exclude: glob:prod-*", "where: size gt
+// 100"); the rule is "" when no rule applies.
+func (f *ResourceFilter) Evaluate(it ncp.ResourceItem) (bool, string) {
+	if !f.IsEnabled() {
+		return false, "enabled: false"
+	}
+	id := it.ID
+	if id == "" {
+		id = it.Name // login keys and buckets are identified by name
+	}
+	var kept []string
 	if len(f.Include) > 0 {
-		allowed = false
-		for _, s := range f.Include {
-			if s == name || s == id {
-				allowed = true
-				break
+		p, ok := firstMatch(f.Include, it.Name, id)
+		if !ok {
+			return false, "include: 일치 항목 없음"
+		}
+		kept = append(kept, "include: "+p)
+	}
+	if p, ok := firstMatch(f.Exclude, it.Name, id); ok {
+		return false, "exclude: " + p
+	}
+	if len(f.IncludeTags) > 0 || len(f.ExcludeTags) > 0 {
+		if it.TagsUnknown {
+			return false, RuleTagsUnknown
+		}
+		if it.Untagged {
+			// Nothing to match: say so, since the keep tag cannot protect it.
+			if len(f.IncludeTags) > 0 {
+				return false, "include_tags: 태그 미지원 종류"
+			}
+			kept = append(kept, "tags: 태그 미지원 종류")
+		}
+		if t, ok := firstTag(f.ExcludeTags, it.Tags); ok {
+			return false, "exclude_tags: " + t
+		}
+		if len(f.IncludeTags) > 0 {
+			t, ok := firstTag(f.IncludeTags, it.Tags)
+			if !ok {
+				return false, "include_tags: 일치 태그 없음"
+			}
+			kept = append(kept, "include_tags: "+t)
+		}
+	}
+	for _, c := range f.Where {
+		if !c.Holds(it) {
+			return false, "where: " + c.String()
+		}
+		kept = append(kept, "where: "+c.String())
+	}
+	if f.OlderThan > 0 || f.NewerThan > 0 {
+		created, ok := it.CreatedTime()
+		if !ok {
+			return false, "older_than/newer_than: 생성일 없음"
+		}
+		age := time.Since(created)
+		if f.OlderThan > 0 {
+			if age < time.Duration(f.OlderThan) {
+				return false, "older_than: " + f.OlderThan.String()
+			}
+			kept = append(kept, "older_than: "+f.OlderThan.String())
+		}
+		if f.NewerThan > 0 {
+			if age >= time.Duration(f.NewerThan) {
+				return false, "newer_than: " + f.NewerThan.String()
+			}
+			kept = append(kept, "newer_than: "+f.NewerThan.String())
+		}
+	}
+	return true, strings.Join(kept, ", ")
+}
+
+// Validate checks that every Include / Exclude entry is a valid pattern,
+// every Where condition is well-formed and the age range is not empty.
+func (f *ResourceFilter) Validate() error {
+	if f.OlderThan < 0 || f.NewerThan < 0 {
+		return fmt.Errorf("older_than, newer_than 은 0 이상이어야 합니다")
+	}
+	if f.OlderThan > 0 && f.NewerThan > 0 && f.OlderThan >= f.NewerThan {
+		return fmt.Errorf("older_than(%s) 이 newer_than(%s) 이상이면 어떤 리소스도 남지 않습니다", f.OlderThan, f.NewerThan)
+	}
+	for i, c := range f.Where {
+		if err := c.Validate(); err != nil {
+			return fmt.Errorf("where[%d]: %w", i, err)
+		}
+	}
+	for _, list := range []struct {
+		name    string
+		entries []string
+	}{{"include_tags", f.IncludeTags}, {"exclude_tags", f.ExcludeTags}} {
+		for i, t := range list.entries {
+			if err := ValidateTag(t); err != nil {
+				return fmt.Errorf("%s[%d]: %w", list.name, i, err)
 			}
 		}
 	}
+	for _, list := range []struct {
+		name    string
+		entries []string
+	}{{"include", f.Include}, {"exclude", f.Exclude}} {
+		for i, p := range list.entries {
+			if err := ValidatePattern(p); err != nil {
+				return fmt.Errorf("%s[%d]: %w", list.name, i, err)
+			}
+		}
+	}
+	return nil
+}
 
-	if !allowed {
+// matchAny reports whether name or id matches one of patterns.
+func matchAny(patterns []string, name, id string) bool {
+	_, ok := firstMatch(patterns, name, id)
+	return ok
+}
+
+// firstMatch returns the first of patterns that name or id matches.
+func firstMatch(patterns []string, name, id string) (string, bool) {
+	for _, p := range patterns {
+		if MatchPattern(p, name) || MatchPattern(p, id) {
+			return p, true
+		}
+	}
+	return "", false
+}
+
+// firstTag returns the first of entries that tags match.
+func firstTag(entries []string, tags map[string]string) (string, bool) {
+	for _, e := range entries {
+		if MatchTag(e, tags) {
+			return e, true
+		}
+	}
+	return "", false
+}
+
+// MatchTag reports whether tags hold the tag entry "key" (any value) or
+// "key=value" (value is a pattern).
+func MatchTag(entry string, tags map[string]string) bool {
+	key, value, hasValue := strings.Cut(entry, "=")
+	v, ok := tags[key]
+	if !ok {
 		return false
 	}
+	return !hasValue || v == value || MatchPattern(value, v)
+}
 
-	// Check Exclude list
-	if len(f.Exclude) > 0 {
-		for _, s := range f.Exclude {
-			if s == name || s == id {
-				return false
+// ValidateTag checks a tag entry: a non-empty key and a valid value pattern.
+func ValidateTag(entry string) error {
+	key, value, hasValue := strings.Cut(entry, "=")
+	if key == "" {
+		return fmt.Errorf("태그 키가 비어 있습니다: %q (key 또는 key=value)", entry)
+	}
+	if hasValue {
+		return ValidatePattern(value)
+	}
+	return nil
+}
+
+// Condition is a property rule of a filter, e.g. {"field": "status", "op":
+// "eq", "value": "NSTOP"} or {"field": "size", "op": "gt", "value": 100}.
+// A resource without the property (e.g. size of a VPC) never meets it.
+type Condition struct {
+	Field string `json:"field"` // one of ConditionFields
+	Op    string `json:"op"`    // one of ConditionOps
+	Value any    `json:"value"` // a string or number; a list for in / not_in
+}
+
+// ConditionFields are the resource properties a Condition can test.
+var ConditionFields = map[string]string{
+	"name":    "이름",
+	"id":      "ID",
+	"status":  "상태 코드 (예: RUN, NSTOP, CREAT)",
+	"vpc":     "상위 VPC 번호 또는 이름",
+	"subnet":  "Subnet 번호 또는 이름",
+	"spec":    "서버 스펙 / 상품 코드",
+	"size":    "스토리지 크기 (GB)",
+	"engine":  "DB 엔진 버전 (예: MYSQL8.0.36)",
+	"created": "생성일 (API 값 그대로)",
+}
+
+// ConditionOps are the operators of a Condition. gt/ge/lt/le compare
+// numbers; eq/ne/in/not_in compare case-insensitively; match takes a
+// filter pattern (glob:, regex:, prefix: or exact).
+var ConditionOps = []string{"eq", "ne", "in", "not_in", "gt", "ge", "lt", "le", "match"}
+
+// values returns the condition's value(s) as strings.
+func (c Condition) values() []string {
+	switch v := c.Value.(type) {
+	case []any:
+		out := make([]string, len(v))
+		for i, x := range v {
+			out[i] = fmt.Sprint(x)
+		}
+		return out
+	case []string:
+		return v
+	case nil:
+		return nil
+	}
+	return []string{fmt.Sprint(c.Value)}
+}
+
+// property returns the values of field for it: the number and the name for
+// vpc / subnet, none when the resource lacks it.
+func property(it ncp.ResourceItem, field string) []string {
+	var vals []string
+	add := func(list ...string) {
+		for _, v := range list {
+			for _, x := range strings.Split(v, ",") {
+				if x != "" {
+					vals = append(vals, x)
+				}
 			}
 		}
 	}
+	switch field {
+	case "name":
+		add(it.Name)
+	case "id":
+		add(it.ID)
+	case "status":
+		add(it.Status)
+	case "vpc":
+		add(it.VpcNo, it.VpcName)
+	case "subnet":
+		add(it.SubnetNo, it.SubnetName)
+	case "spec":
+		add(it.Spec)
+	case "size":
+		if it.Size > 0 {
+			vals = append(vals, strconv.FormatInt(it.Size, 10))
+		}
+	case "engine":
+		add(it.Engine)
+	case "created":
+		add(it.Created)
+	}
+	return vals
+}
 
-	return true
+// Holds reports whether it meets the condition.
+func (c Condition) Holds(it ncp.ResourceItem) bool {
+	props := property(it, c.Field)
+	if len(props) == 0 {
+		return false
+	}
+	want := c.values()
+	switch c.Op {
+	case "ne", "not_in":
+		return !anyEqual(props, want)
+	case "eq", "in":
+		return anyEqual(props, want)
+	case "match":
+		for _, p := range props {
+			for _, w := range want {
+				if MatchPattern(w, p) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	// Numeric comparison.
+	if len(want) != 1 {
+		return false
+	}
+	limit, err := strconv.ParseFloat(want[0], 64)
+	if err != nil {
+		return false
+	}
+	for _, p := range props {
+		n, err := strconv.ParseFloat(p, 64)
+		if err != nil {
+			continue
+		}
+		switch {
+		case c.Op == "gt" && n > limit, c.Op == "ge" && n >= limit,
+			c.Op == "lt" && n < limit, c.Op == "le" && n <= limit:
+			return true
+		}
+	}
+	return false
+}
+
+func anyEqual(props, want []string) bool {
+	for _, p := range props {
+		for _, w := range want {
+			if strings.EqualFold(p, w) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Validate checks the field, the operator and the value of c.
+func (c Condition) Validate() error {
+	if _, ok := ConditionFields[c.Field]; !ok {
+		fields := make([]string, 0, len(ConditionFields))
+		for f := range ConditionFields {
+			fields = append(fields, f)
+		}
+		sort.Strings(fields)
+		return fmt.Errorf("알 수 없는 필드 %q (%s 중 하나)", c.Field, strings.Join(fields, ", "))
+	}
+	want := c.values()
+	switch c.Op {
+	case "eq", "ne":
+		if len(want) != 1 {
+			return fmt.Errorf("%s 의 값은 하나여야 합니다", c.Op)
+		}
+	case "in", "not_in":
+		if len(want) == 0 {
+			return fmt.Errorf("%s 의 값이 비어 있습니다", c.Op)
+		}
+	case "match":
+		if len(want) == 0 {
+			return fmt.Errorf("match 의 값이 비어 있습니다")
+		}
+		for _, w := range want {
+			if err := ValidatePattern(w); err != nil {
+				return err
+			}
+		}
+	case "gt", "ge", "lt", "le":
+		if len(want) != 1 {
+			return fmt.Errorf("%s 의 값은 숫자 하나여야 합니다", c.Op)
+		}
+		if _, err := strconv.ParseFloat(want[0], 64); err != nil {
+			return fmt.Errorf("%s 의 값은 숫자여야 합니다: %v", c.Op, c.Value)
+		}
+	default:
+		return fmt.Errorf("알 수 없는 연산자 %q (%s 중 하나)", c.Op, strings.Join(ConditionOps, ", "))
+	}
+	return nil
+}
+
+// String renders c as "field op value".
+func (c Condition) String() string {
+	v := c.values()
+	if len(v) == 1 && c.Op != "in" && c.Op != "not_in" {
+		return fmt.Sprintf("%s %s %s", c.Field, c.Op, v[0])
+	}
+	return fmt.Sprintf("%s %s [%s]", c.Field, c.Op, strings.Join(v, ", "))
+}
+
+// Duration is a length of time written as a Go duration ("72h", "90m") or
+// a whole number of days ("7d").
+type Duration time.Duration
+
+// ParseDuration parses s as a Duration.
+func ParseDuration(s string) (Duration, error) {
+	s = strings.TrimSpace(s)
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("잘못된 기간 %q (예: 72h, 7d)", s)
+		}
+		return Duration(time.Duration(n) * 24 * time.Hour), nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("잘못된 기간 %q (예: 72h, 7d)", s)
+	}
+	return Duration(d), nil
+}
+
+// String renders d as ParseDuration reads it, e.g. "7d", "36h", "1h30m".
+func (d Duration) String() string {
+	if d != 0 && time.Duration(d)%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%dd", time.Duration(d)/(24*time.Hour))
+	}
+	s := time.Duration(d).String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("기간은 문자열이어야 합니다 (예: \"72h\", \"7d\"): %s", data)
+	}
+	v, err := ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// Pattern forms of filter entries. An entry without one of these prefixes
+// matches exactly.
+const (
+	PatternGlob   = "glob:"   // shell glob: * ? [a-z]
+	PatternRegex  = "regex:"  // Go regular expression, unanchored
+	PatternPrefix = "prefix:" // leading string
+	PatternExact  = "exact:"  // the rest verbatim, for values that start with a prefix
+)
+
+// regexCache holds compiled regex: patterns, keyed by the expression.
+var regexCache sync.Map
+
+// MatchPattern reports whether s matches the filter entry pattern. Invalid
+// patterns (rejected by ValidatePattern) match nothing, and an empty s
+// never matches.
+func MatchPattern(pattern, s string) bool {
+	if s == "" {
+		return false
+	}
+	switch {
+	case strings.HasPrefix(pattern, PatternGlob):
+		ok, err := path.Match(strings.TrimPrefix(pattern, PatternGlob), s)
+		return err == nil && ok
+	case strings.HasPrefix(pattern, PatternRegex):
+		re, err := compileRegex(strings.TrimPrefix(pattern, PatternRegex))
+		return err == nil && re.MatchString(s)
+	case strings.HasPrefix(pattern, PatternPrefix):
+		return strings.HasPrefix(s, strings.TrimPrefix(pattern, PatternPrefix))
+	case strings.HasPrefix(pattern, PatternExact):
+		return s == strings.TrimPrefix(pattern, PatternExact)
+	}
+	return s == pattern
+}
+
+// ValidatePattern checks a filter entry: glob and regex patterns must
+// compile and no form may be empty.
+func ValidatePattern(pattern string) error {
+	for _, prefix := range []string{PatternGlob, PatternRegex, PatternPrefix, PatternExact} {
+		if rest, ok := strings.CutPrefix(pattern, prefix); ok {
+			if rest == "" {
+				return fmt.Errorf("빈 패턴: %q", pattern)
+			}
+			switch prefix {
+			case PatternGlob:
+				if _, err := path.Match(rest, ""); err != nil {
+					return fmt.Errorf("잘못된 glob 패턴 %q: %w", pattern, err)
+				}
+			case PatternRegex:
+				if _, err := compileRegex(rest); err != nil {
+					return fmt.Errorf("잘못된 정규식 %q: %w", pattern, err)
+				}
+			}
+			return nil
+		}
+	}
+	if pattern == "" {
+		return fmt.Errorf("빈 항목")
+	}
+	return nil
+}
+
+// Literal returns an entry matching exactly s, even when s itself starts
+// with a pattern prefix.
+func Literal(s string) string {
+	for _, prefix := range []string{PatternGlob, PatternRegex, PatternPrefix, PatternExact} {
+		if strings.HasPrefix(s, prefix) {
+			return PatternExact + s
+		}
+	}
+	return s
+}
+
+func compileRegex(expr string) (*regexp.Regexp, error) {
+	if re, ok := regexCache.Load(expr); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	regexCache.Store(expr, re)
+	return re, nil
 }
 
 type Config struct {
-	Servers               ResourceFilter `json:"servers"`
-	BlockStorages         ResourceFilter `json:"block_storages"`
-	BlockStorageSnapshots ResourceFilter `json:"block_storage_snapshots"`
-	PublicIps             ResourceFilter `json:"public_ips"`
-	NasVolumes            ResourceFilter `json:"nas_volumes"`
-	NasVolumeSnapshots    ResourceFilter `json:"nas_volume_snapshots"`
-	LoadBalancers         ResourceFilter `json:"load_balancers"`
-	TargetGroups          ResourceFilter `json:"target_groups"`
-	CloudDBs              ResourceFilter `json:"cloud_dbs"`
-	CloudPostgresqls      ResourceFilter `json:"cloud_postgresqls"`
-	CloudMongoDBs         ResourceFilter `json:"cloud_mongodbs"`
-	CloudMariaDBs         ResourceFilter `json:"cloud_mariadbs"`
-	CloudMySQLs           ResourceFilter `json:"cloud_mysqls"`
-	CloudRedises          ResourceFilter `json:"cloud_redises"`
-	Vpcs                  ResourceFilter `json:"vpcs"`
-	Subnets               ResourceFilter `json:"subnets"`
-	NatGateways           ResourceFilter `json:"nat_gateways"`
-	VpcPeerings           ResourceFilter `json:"vpc_peerings"`
-	NetworkAcls           ResourceFilter `json:"network_acls"`
-	AccessControlGroups   ResourceFilter `json:"access_control_groups"`
-	AutoScalingGroups     ResourceFilter `json:"auto_scaling_groups"`
-	LaunchConfigurations  ResourceFilter `json:"launch_configurations"`
-	NksClusters           ResourceFilter `json:"nks_clusters"`
-	InitScripts           ResourceFilter `json:"init_scripts"`
-	LoginKeys             ResourceFilter `json:"login_keys"`
-	PlacementGroups       ResourceFilter `json:"placement_groups"`
-	Buckets               ResourceFilter `json:"buckets"`
-	ApiGatewayProducts    ResourceFilter `json:"api_gateway_products"`
+	// Filters holds the filter of each resource type, keyed by its config
+	// key (ncp.ResourceType.Key, e.g. "servers"). In JSON every filter is a
+	// top-level key of its own; a missing filter matches everything.
+	Filters map[string]ResourceFilter `json:"-"`
+
+	// Regions lists the region codes to scan and clean (e.g. ["KR", "SGN"]).
+	// ["all"] expands to every region from getRegionList; empty keeps the
+	// API default region (KR).
+	Regions []string `json:"regions,omitempty"`
+
+	// Site is the NCP cloud site (public, fin, gov) for accounts whose Excel
+	// row has no Site value. Empty means public.
+	Site string `json:"site,omitempty"`
+
+	// Platform selects the platforms to scan and clean (auto, vpc, classic,
+	// all) for accounts whose Excel row has no Platform value. Empty means
+	// auto: VPC, plus Classic when the account still supports it.
+	Platform string `json:"platform,omitempty"`
+
+	// Endpoints overrides NCP API base URLs per service (e.g. "vserver",
+	// "vpc", "objectstorage"), for pointing the tool at a fake or proxy.
+	Endpoints map[string]string `json:"endpoints,omitempty"`
+
+	// API tunes request retries and client-side rate limiting.
+	API APIConfig `json:"api,omitempty"`
+
+	// Workers is how many resources are deleted at once per account and
+	// region. Zero keeps ncp.DefaultWorkers.
+	Workers int `json:"workers,omitempty"`
+
+	// MaxPasses is how many delete passes run per region: after each pass
+	// the region is re-scanned and the leftovers are retried. Zero keeps
+	// runner.DefaultMaxPasses.
+	MaxPasses int `json:"max_passes,omitempty"`
+
+	// JournalDir is where destructive runs write their operation journal
+	// (see package journal). Empty means the current directory; "-"
+	// disables the journal.
+	JournalDir string `json:"journal_dir,omitempty"`
+
+	// OlderThan and NewerThan are the age limits of every filter that sets
+	// none of its own (see ResourceFilter.OlderThan).
+	OlderThan Duration `json:"older_than,omitempty"`
+	NewerThan Duration `json:"newer_than,omitempty"`
+
+	// KeepTag is the tag ("key=value" or "key") that protects a resource
+	// from every filter. Empty means DefaultKeepTag; "-" disables it.
+	KeepTag string `json:"keep_tag,omitempty"`
+
+	// VpcScope limits a run to the resources inside these VPCs (numbers or
+	// names): the VPCs themselves and everything that belongs to them or to
+	// their servers. Resources outside any VPC are left alone.
+	VpcScope []string `json:"vpc_scope,omitempty"`
+
+	// Accounts and Groups override the settings above for the root accounts
+	// with that AccountName or Excel Group label (see ForAccount). Their
+	// filters replace the default filter of the same type.
+	Accounts map[string]*Config `json:"accounts,omitempty"`
+	Groups   map[string]*Config `json:"groups,omitempty"`
+
+	// AccountGuard limits every run to the NCP accounts it allows, by the
+	// member number resolved from each account's access key.
+	AccountGuard *AccountGuard `json:"account_guard,omitempty"`
+
+	overrides []Overrides // see Overrides.Apply
+}
+
+// AccountGuard allows or forbids NCP accounts by member number. When it
+// lists any, an account whose member number cannot be resolved is refused.
+type AccountGuard struct {
+	Allow []string `json:"allow,omitempty"` // if set, only these accounts are touched
+	Deny  []string `json:"deny,omitempty"`  // never touched, even if allowed
+}
+
+// Validate checks that no entry is empty or both allowed and denied.
+func (g *AccountGuard) Validate() error {
+	if g == nil {
+		return nil
+	}
+	deny := make(map[string]bool)
+	for i, no := range g.Deny {
+		if strings.TrimSpace(no) == "" {
+			return fmt.Errorf("deny[%d]: 빈 회원 번호", i)
+		}
+		deny[strings.TrimSpace(no)] = true
+	}
+	for i, no := range g.Allow {
+		if strings.TrimSpace(no) == "" {
+			return fmt.Errorf("allow[%d]: 빈 회원 번호", i)
+		}
+		if deny[strings.TrimSpace(no)] {
+			return fmt.Errorf("allow[%d]: %s 가 deny 에도 있습니다", i, no)
+		}
+	}
+	return nil
+}
+
+// Guarded reports whether cfg (which may be nil) has an account guard.
+func Guarded(cfg *Config) bool {
+	return cfg != nil && cfg.AccountGuard != nil && (len(cfg.AccountGuard.Allow) > 0 || len(cfg.AccountGuard.Deny) > 0)
+}
+
+// CheckAccount checks the member number of an account ("" when it could
+// not be resolved) against the account guard of cfg (cfg may be nil).
+func CheckAccount(cfg *Config, memberNo string) error {
+	if !Guarded(cfg) {
+		return nil
+	}
+	g := cfg.AccountGuard
+	if memberNo == "" {
+		// The member number only comes from Billing contracts, so a key
+		// without billing permission or an account without recent
+		// contracts cannot be identified.
+		return fmt.Errorf("회원 번호를 확인할 수 없어 account_guard 를 적용할 수 없습니다 (회원 번호는 Billing API 의 이번 달·지난달 계약 내역에서만 읽으므로, 계약이 없는 계정이나 비용 조회 권한이 없는 키는 확인할 수 없습니다)")
+	}
+	has := func(list []string) bool {
+		for _, no := range list {
+			if strings.TrimSpace(no) == memberNo {
+				return true
+			}
+		}
+		return false
+	}
+	if has(g.Deny) {
+		return fmt.Errorf("회원 번호 %s 는 account_guard.deny 에 있습니다", memberNo)
+	}
+	if len(g.Allow) > 0 && !has(g.Allow) {
+		return fmt.Errorf("회원 번호 %s 는 account_guard.allow 에 없습니다", memberNo)
+	}
+	return nil
+}
+
+// RuleTagsUnknown is the rule of Evaluate for a resource whose tags could
+// not be listed: it is not deleted, since it may carry the keep tag.
+const RuleTagsUnknown = "tags: 태그 조회 실패"
+
+// DefaultKeepTag protects tagged resources unless the config sets another
+// KeepTag.
+const DefaultKeepTag = "ncp-nuke:keep=true"
+
+// KeepTagDisabled is the KeepTag value that turns the keep tag off.
+const KeepTagDisabled = "-"
+
+// APIConfig overrides the NCP client's retry policy, rate limit and list page
+// size. Zero values keep the client defaults.
+type APIConfig struct {
+	MaxAttempts int     `json:"max_attempts,omitempty"` // total attempts per call (429/5xx/network errors)
+	RateLimit   float64 `json:"rate_limit,omitempty"`   // requests per second per access key
+	RateBurst   int     `json:"rate_burst,omitempty"`
+	PageSize    int     `json:"page_size,omitempty"` // rows per page for list APIs
+}
+
+// Filter returns the filter of the resource type with the given config key,
+// with the config-wide age limits filled in when it sets none and, for the
+// types that have tags, the keep tag added to its ExcludeTags.
+func (c *Config) Filter(key string) ResourceFilter {
+	if c == nil {
+		if !hasTags(key) {
+			return ResourceFilter{}
+		}
+		return ResourceFilter{ExcludeTags: []string{DefaultKeepTag}}
+	}
+	f := c.Filters[key]
+	if f.OlderThan == 0 && f.NewerThan == 0 {
+		f.OlderThan, f.NewerThan = c.OlderThan, c.NewerThan
+	}
+	if keep := c.keepTag(); keep != "" && hasTags(key) {
+		f.ExcludeTags = append([]string{keep}, f.ExcludeTags...)
+	}
+	if len(c.VpcScope) > 0 {
+		f.Where = append([]Condition{c.vpcCondition()}, f.Where...)
+	}
+	return f
+}
+
+// hasTags reports whether the resource type with the given config key has
+// tags the keep tag could match; unknown keys are assumed to.
+func hasTags(key string) bool {
+	rt, ok := ncp.LookupResourceType(key)
+	return !ok || rt.Tagged()
+}
+
+// vpcCondition is the Where condition of VpcScope.
+func (c *Config) vpcCondition() Condition {
+	return Condition{Field: "vpc", Op: "in", Value: c.VpcScope}
+}
+
+// InVpcScope reports whether it is inside the VpcScope of cfg (cfg may be
+// nil); everything is when no scope is set.
+func InVpcScope(cfg *Config, it ncp.ResourceItem) bool {
+	if cfg == nil || len(cfg.VpcScope) == 0 {
+		return true
+	}
+	return cfg.vpcCondition().Holds(it)
+}
+
+// keepTag returns the effective KeepTag, "" when disabled.
+func (c *Config) keepTag() string {
+	switch c.KeepTag {
+	case "":
+		return DefaultKeepTag
+	case KeepTagDisabled:
+		return ""
+	}
+	return c.KeepTag
+}
+
+// Protected reports whether it carries the keep tag of cfg (cfg may be nil).
+func Protected(cfg *Config, it ncp.ResourceItem) bool {
+	keep := DefaultKeepTag
+	if cfg != nil {
+		keep = cfg.keepTag()
+	}
+	return keep != "" && MatchTag(keep, it.Tags)
+}
+
+// SetFilter sets the filter of the resource type with the given config key.
+func (c *Config) SetFilter(key string, f ResourceFilter) {
+	if c.Filters == nil {
+		c.Filters = make(map[string]ResourceFilter)
+	}
+	c.Filters[key] = f
+}
+
+// configFields is Config without its methods, for the default JSON coding of
+// the non-filter fields.
+type configFields Config
+
+// UnmarshalJSON reads the per-type filters from the top-level keys named after
+// the registered resource types.
+func (c *Config) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, (*configFields)(c)); err != nil {
+		return err
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	for _, rt := range ncp.ResourceTypes() {
+		msg, ok := raw[rt.Key()]
+		if !ok {
+			continue
+		}
+		var f ResourceFilter
+		if err := json.Unmarshal(msg, &f); err != nil {
+			return fmt.Errorf("%s: %w", rt.Key(), err)
+		}
+		c.SetFilter(rt.Key(), f)
+	}
+	return nil
+}
+
+// MarshalJSON writes the per-type filters as top-level keys, in registry order.
+func (c Config) MarshalJSON() ([]byte, error) {
+	base, err := json.Marshal(configFields(c))
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for _, rt := range ncp.ResourceTypes() {
+		f, ok := c.Filters[rt.Key()]
+		if !ok {
+			continue
+		}
+		b, err := json.Marshal(f)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&buf, "%q:%s,", rt.Key(), b)
+	}
+	buf.Write(base[1:])
+	if buf.Len() > 2 && base[1] == '}' {
+		// No other fields: drop the trailing comma before the closing brace.
+		out := buf.Bytes()
+		return append(out[:len(out)-2], '}'), nil
+	}
+	return buf.Bytes(), nil
+}
+
+// Validate checks the patterns of every filter, the age limits, the account
+// guard and the account and group sections.
+func (c *Config) Validate() error {
+	if c == nil {
+		return nil
+	}
+	for _, sections := range []struct {
+		name string
+		m    map[string]*Config
+	}{{"groups", c.Groups}, {"accounts", c.Accounts}} {
+		for _, key := range sortedKeys(sections.m) {
+			sec := sections.m[key]
+			if err := sec.validateSection(); err != nil {
+				return fmt.Errorf("%s.%s: %w", sections.name, key, err)
+			}
+		}
+	}
+	age := ResourceFilter{OlderThan: c.OlderThan, NewerThan: c.NewerThan}
+	if err := age.Validate(); err != nil {
+		return err
+	}
+	if err := c.AccountGuard.Validate(); err != nil {
+		return fmt.Errorf("account_guard: %w", err)
+	}
+	if keep := c.keepTag(); keep != "" {
+		if err := ValidateTag(keep); err != nil {
+			return fmt.Errorf("keep_tag: %w", err)
+		}
+	}
+	for _, rt := range ncp.ResourceTypes() {
+		f, ok := c.Filters[rt.Key()]
+		if !ok {
+			continue
+		}
+		if err := f.Validate(); err != nil {
+			return fmt.Errorf("%s 필터 %w", rt.Key(), err)
+		}
+	}
+	return nil
+}
+
+// validateSection checks an account or group section, which cannot nest
+// sections or move the run-wide journal.
+func (c *Config) validateSection() error {
+	if c == nil {
+		return nil
+	}
+	if len(c.Accounts) > 0 || len(c.Groups) > 0 {
+		return fmt.Errorf("accounts, groups 는 최상위에만 둘 수 있습니다")
+	}
+	if c.JournalDir != "" {
+		return fmt.Errorf("journal_dir 는 최상위에만 둘 수 있습니다")
+	}
+	if c.AccountGuard != nil {
+		return fmt.Errorf("account_guard 는 최상위에만 둘 수 있습니다")
+	}
+	if _, err := ncp.ParseSite(c.Site); err != nil {
+		return err
+	}
+	if _, err := ncp.ParsePlatform(c.Platform); err != nil {
+		return err
+	}
+	return c.Validate()
+}
+
+func sortedKeys(m map[string]*Config) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ForAccount returns the effective config of account: c with the section of
+// its Group (matched case-insensitively) and then its own section merged
+// in. It returns c itself (possibly nil) when no section applies.
+func (c *Config) ForAccount(account ncp.RootAccount) *Config {
+	if c == nil || len(c.Accounts) == 0 && len(c.Groups) == 0 {
+		return c
+	}
+	var sections []*Config
+	if account.Group != "" {
+		for _, key := range sortedKeys(c.Groups) {
+			if strings.EqualFold(key, account.Group) && c.Groups[key] != nil {
+				sections = append(sections, c.Groups[key])
+			}
+		}
+	}
+	if sec := c.Accounts[account.AccountName]; sec != nil {
+		sections = append(sections, sec)
+	}
+	out := *c
+	out.Accounts, out.Groups = nil, nil
+	for _, sec := range sections {
+		out.merge(sec)
+	}
+	for _, o := range c.overrides {
+		o.set(&out)
+	}
+	return &out
+}
+
+// merge overrides c with the settings o sets. Maps are copied, never shared
+// with o or the config c was copied from.
+func (c *Config) merge(o *Config) {
+	filters := make(map[string]ResourceFilter, len(c.Filters)+len(o.Filters))
+	for k, f := range c.Filters {
+		filters[k] = f
+	}
+	for k, f := range o.Filters {
+		filters[k] = f
+	}
+	c.Filters = filters
+	if len(o.Endpoints) > 0 {
+		endpoints := make(map[string]string, len(c.Endpoints)+len(o.Endpoints))
+		for k, v := range c.Endpoints {
+			endpoints[k] = v
+		}
+		for k, v := range o.Endpoints {
+			endpoints[k] = v
+		}
+		c.Endpoints = endpoints
+	}
+	if len(o.Regions) > 0 {
+		c.Regions = o.Regions
+	}
+	if o.Site != "" {
+		c.Site = o.Site
+	}
+	if o.Platform != "" {
+		c.Platform = o.Platform
+	}
+	if o.API.MaxAttempts > 0 {
+		c.API.MaxAttempts = o.API.MaxAttempts
+	}
+	if o.API.RateLimit > 0 {
+		c.API.RateLimit, c.API.RateBurst = o.API.RateLimit, o.API.RateBurst
+	}
+	if o.API.PageSize > 0 {
+		c.API.PageSize = o.API.PageSize
+	}
+	if o.Workers > 0 {
+		c.Workers = o.Workers
+	}
+	if o.MaxPasses > 0 {
+		c.MaxPasses = o.MaxPasses
+	}
+	if o.OlderThan > 0 || o.NewerThan > 0 {
+		c.OlderThan, c.NewerThan = o.OlderThan, o.NewerThan
+	}
+	if o.KeepTag != "" {
+		c.KeepTag = o.KeepTag
+	}
+	if len(o.VpcScope) > 0 {
+		c.VpcScope = o.VpcScope
+	}
+}
+
+// AllRegions is the Regions value that selects every available region.
+const AllRegions = "all"
+
+// ParseRegions splits a comma-separated region list such as "KR,SGN" or
+// "all", dropping empty entries.
+func ParseRegions(s string) []string {
+	var out []string
+	for _, r := range strings.Split(s, ",") {
+		if r = strings.TrimSpace(r); r != "" {
+			if strings.EqualFold(r, AllRegions) {
+				return []string{AllRegions}
+			}
+			out = append(out, strings.ToUpper(r))
+		}
+	}
+	return out
 }
 
+// Overrides are the settings given on the command line. They win over the
+// config file at every level: Apply sets them on the config, and ForAccount
+// sets them again over the account and group sections. Zero values are
+// unset.
+type Overrides struct {
+	Regions    []string
+	Site       string
+	Platform   string
+	Workers    int
+	MaxPasses  int
+	JournalDir string
+	OlderThan  Duration
+	NewerThan  Duration
+	VpcScope   []string
+}
+
+// IsZero reports whether no override is set.
+func (o Overrides) IsZero() bool {
+	return len(o.Regions) == 0 && o.Site == "" && o.Platform == "" && o.Workers <= 0 && o.MaxPasses <= 0 &&
+		o.JournalDir == "" && o.OlderThan <= 0 && o.NewerThan <= 0 && len(o.VpcScope) == 0
+}
+
+// Apply returns cfg with the overrides set, allocating an empty config if
+// cfg is nil (cfg itself when none is set). Overrides applied later win.
+func (o Overrides) Apply(cfg *Config) *Config {
+	if o.IsZero() {
+		return cfg
+	}
+	if cfg == nil {
+		cfg = &Config{}
+	}
+	// Never append to a slice shared with a copy of cfg.
+	cfg.overrides = append(cfg.overrides[:len(cfg.overrides):len(cfg.overrides)], o)
+	o.set(cfg)
+	return cfg
+}
+
+// set copies the overrides that are set into c.
+func (o Overrides) set(c *Config) {
+	if len(o.Regions) > 0 {
+		c.Regions = o.Regions
+	}
+	if o.Site != "" {
+		c.Site = o.Site
+	}
+	if o.Platform != "" {
+		c.Platform = o.Platform
+	}
+	if o.Workers > 0 {
+		c.Workers = o.Workers
+	}
+	if o.MaxPasses > 0 {
+		c.MaxPasses = o.MaxPasses
+	}
+	if o.JournalDir != "" {
+		c.JournalDir = o.JournalDir
+	}
+	if o.OlderThan > 0 {
+		c.OlderThan = o.OlderThan
+	}
+	if o.NewerThan > 0 {
+		c.NewerThan = o.NewerThan
+	}
+	if len(o.VpcScope) > 0 {
+		c.VpcScope = o.VpcScope
+	}
+}
+
+// JournalDisabled is the JournalDir value that turns the journal off.
+const JournalDisabled = "-"
+
+// JournalDirOf returns the journal directory of cfg (cfg may be nil) and
+// whether journaling is enabled.
+func JournalDirOf(cfg *Config) (string, bool) {
+	if cfg == nil {
+		return "", true
+	}
+	return cfg.JournalDir, cfg.JournalDir != JournalDisabled
+}
+
+// LoadConfig reads a JSON or YAML (.yaml, .yml) config file, strictly (see
+// ParseConfig).
 func LoadConfig(path string) (*Config, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
-	var cfg Config
-	if err := json.Unmarshal(data, &cfg); err != nil {
+	cfg, err := ParseConfig(data, IsYAML(path))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if _, err := ncp.ParseSite(cfg.Site); err != nil {
+		return nil, err
+	}
+	if _, err := ncp.ParsePlatform(cfg.Platform); err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
 		return nil, err
 	}
-	return &cfg, nil
+	return cfg, nil
 }
@@ -0,0 +1,284 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"ncp-nuke/pkg/ncp"
+)
+
+func TestMatchPattern(t *testing.T) {
+	tests := []struct {
+		pattern, s string
+		want       bool
+	}{
+		{"web", "web", true},
+		{"web", "web-1", false},
+		{"glob:web-*", "web-1", true},
+		{"glob:web-?", "web-10", false},
+		{"regex:^db-[0-9]+$", "db-12", true},
+		{"regex:^db-[0-9]+$", "db-x", false},
+		{"regex:test", "my-test-vm", true}, // unanchored
+		{"prefix:lab-", "lab-vpc", true},
+		{"prefix:lab-", "prod-lab-vpc", false},
+		{"exact:glob:*", "glob:*", true},
+		{"exact:glob:*", "anything", false},
+		{"glob:[", "[", false}, // invalid patterns match nothing
+		{"glob:*", "", false},  // an empty value never matches
+	}
+	for _, tt := range tests {
+		if got := MatchPattern(tt.pattern, tt.s); got != tt.want {
+			t.Errorf("MatchPattern(%q, %q) = %v, want %v", tt.pattern, tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestLiteral(t *testing.T) {
+	for _, s := range []string{"web", "glob:*", "regex:.*", "prefix:x", "exact:y"} {
+		if !MatchPattern(Literal(s), s) {
+			t.Errorf("Literal(%q) does not match itself", s)
+		}
+	}
+	if MatchPattern(Literal("glob:*"), "web") {
+		t.Error(`Literal("glob:*") matches "web"`)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	old := time.Now().Add(-72 * time.Hour).Format("2006-01-02T15:04:05-0700")
+	recent := time.Now().Add(-time.Hour).Format("2006-01-02T15:04:05-0700")
+	web := ncp.ResourceItem{Name: "web-1", ID: "100", Status: "RUN", Size: 50, Created: old, VpcNo: "1", VpcName: "lab-vpc", Tags: map[string]string{"env": "dev"}}
+
+	tests := []struct {
+		name     string
+		filter   ResourceFilter
+		it       ncp.ResourceItem
+		want     bool
+		wantRule string
+	}{
+		{"no rule", ResourceFilter{}, web, true, ""},
+		{"disabled", ResourceFilter{Enabled: new(bool)}, web, false, "enabled: false"},
+		{"include", ResourceFilter{Include: []string{"glob:web-*"}}, web, true, "include: glob:web-*"},
+		{"include miss", ResourceFilter{Include: []string{"db"}}, web, false, "include: 일치 항목 없음"},
+		{"include by id", ResourceFilter{Include: []string{"100"}}, web, true, "include: 100"},
+		{"exclude", ResourceFilter{Exclude: []string{"regex:^web"}}, web, false, "exclude: regex:^web"},
+		{"where eq", ResourceFilter{Where: []Condition{{Field: "status", Op: "eq", Value: "run"}}}, web, true, "where: status eq run"},
+		{"where gt", ResourceFilter{Where: []Condition{{Field: "size", Op: "gt", Value: 100}}}, web, false, "where: size gt 100"},
+		{"where in vpc name", ResourceFilter{Where: []Condition{{Field: "vpc", Op: "in", Value: []any{"other", "lab-vpc"}}}}, web, true, "where: vpc in [other, lab-vpc]"},
+		{"where missing property", ResourceFilter{Where: []Condition{{Field: "engine", Op: "ne", Value: "x"}}}, web, false, "where: engine ne x"},
+		{"older than", ResourceFilter{OlderThan: Duration(48 * time.Hour)}, web, true, "older_than: 2d"},
+		{"too new", ResourceFilter{OlderThan: Duration(96 * time.Hour)}, web, false, "older_than: 4d"},
+		{"newer than", ResourceFilter{NewerThan: Duration(2 * time.Hour)}, ncp.ResourceItem{Name: "x", Created: recent}, true, "newer_than: 2h"},
+		{"no creation date", ResourceFilter{NewerThan: Duration(time.Hour)}, ncp.ResourceItem{Name: "rt"}, false, "older_than/newer_than: 생성일 없음"},
+		{"exclude tag", ResourceFilter{ExcludeTags: []string{"env=glob:d*"}}, web, false, "exclude_tags: env=glob:d*"},
+		{"include tag", ResourceFilter{IncludeTags: []string{"env"}}, web, true, "include_tags: env"},
+		{"include tag miss", ResourceFilter{IncludeTags: []string{"env=prod"}}, web, false, "include_tags: 일치 태그 없음"},
+		{"tags unknown", ResourceFilter{ExcludeTags: []string{"keep"}}, ncp.ResourceItem{Name: "x", TagsUnknown: true}, false, "tags: 태그 조회 실패"},
+		{"untagged type", ResourceFilter{ExcludeTags: []string{"keep"}}, ncp.ResourceItem{Name: "key", Untagged: true}, true, "tags: 태그 미지원 종류"},
+		{"untagged include", ResourceFilter{IncludeTags: []string{"env"}}, ncp.ResourceItem{Name: "key", Untagged: true}, false, "include_tags: 태그 미지원 종류"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, rule := tt.filter.Evaluate(tt.it)
+			if got != tt.want || rule != tt.wantRule {
+				t.Errorf("Evaluate = %v, %q, want %v, %q", got, rule, tt.want, tt.wantRule)
+			}
+		})
+	}
+}
+
+func TestFilterKeepTag(t *testing.T) {
+	kept := ncp.ResourceItem{Name: "web", Tags: map[string]string{"ncp-nuke:keep": "true"}}
+	if f := (&Config{}).Filter("servers"); func() bool { ok, _ := f.Evaluate(kept); return ok }() {
+		t.Error("default keep tag does not protect the server")
+	}
+	cfg := &Config{KeepTag: KeepTagDisabled}
+	if f := cfg.Filter("servers"); func() bool { ok, _ := f.Evaluate(kept); return !ok }() {
+		t.Error("disabled keep tag still protects the server")
+	}
+	// Types without tags get no tag rule to report.
+	for _, c := range []*Config{nil, {}} {
+		f := c.Filter("login_keys")
+		if len(f.ExcludeTags) > 0 {
+			t.Errorf("login key filter %+v, want no keep tag", f)
+		}
+		if _, rule := f.Evaluate(ncp.ResourceItem{Name: "key", Untagged: true}); rule != "" {
+			t.Errorf("login key rule %q, want none", rule)
+		}
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	for s, want := range map[string]time.Duration{"7d": 7 * 24 * time.Hour, "36h": 36 * time.Hour, "90m": 90 * time.Minute} {
+		d, err := ParseDuration(s)
+		if err != nil || time.Duration(d) != want {
+			t.Errorf("ParseDuration(%q) = %v, %v, want %v", s, d, err, want)
+		}
+	}
+	for _, s := range []string{"", "7", "xd", "1w"} {
+		if _, err := ParseDuration(s); err == nil {
+			t.Errorf("ParseDuration(%q): want an error", s)
+		}
+	}
+}
+
+func TestParseConfig(t *testing.T) {
+	yml := `regions: [KR]
+older_than: 7d
+servers:
+  include: ["glob:lab-*"]
+  where:
+    - {field: status, op: eq, value: NSTOP}
+accounts:
+  Student-01:
+    workers: 2
+`
+	cfg, err := ParseConfig([]byte(yml), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if time.Duration(cfg.OlderThan) != 7*24*time.Hour {
+		t.Errorf("older_than = %v", cfg.OlderThan)
+	}
+	if f := cfg.Filters["servers"]; len(f.Include) != 1 || len(f.Where) != 1 || f.Where[0].Value != "NSTOP" {
+		t.Errorf("servers filter = %+v", f)
+	}
+	if cfg.Accounts["Student-01"].Workers != 2 {
+		t.Errorf("account section = %+v", cfg.Accounts["Student-01"])
+	}
+
+	json := `{
+	"servers": {"include": ["web"]},
+	"login_keys": {"exclude": ["a"]}
+}`
+	if _, err := ParseConfig([]byte(json), false); err != nil {
+		t.Errorf("JSON: %v", err)
+	}
+	if cfg, err := ParseConfig(nil, true); err != nil || cfg == nil {
+		t.Errorf("empty file: %v, %v", cfg, err)
+	}
+}
+
+func TestParseConfigErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   string
+		yaml   bool
+		line   int
+		field  string
+		errHas string
+	}{
+		{"unknown field", "regions: [KR]\nservers:\n  exclud: [a]\n", true, 3, "servers.exclud", `"exclude"`},
+		{"unknown type", "severs:\n  include: [a]\n", true, 1, "severs", `"servers"`},
+		{"bad pattern", "servers:\n  exclude:\n    - a\n    - \"glob:[\"\n", true, 4, "servers.exclude[1]", "glob"},
+		{"bad condition", "servers:\n  where:\n    - {field: colour, op: eq, value: red}\n", true, 3, "servers.where[0]", ""},
+		{"bad duration", "older_than: 7x\n", true, 1, "older_than", ""},
+		{"wrong type", "workers: many\n", true, 1, "workers", ""},
+		{"duplicate", "servers:\n  include: [a]\nservers:\n  include: [b]\n", true, 3, "servers", "1번째 줄"},
+		{"JSON unknown field", "{\n\t\"regions\": [\"KR\"],\n\t\"workerz\": 2\n}", false, 3, "workerz", `"workers"`},
+		{"JSON syntax", "{\n\t\"regions\": [\"KR\"\n}", false, 3, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseConfig([]byte(tt.data), tt.yaml)
+			var fe *FieldError
+			if !errors.As(err, &fe) {
+				t.Fatalf("err = %v, want a *FieldError", err)
+			}
+			if fe.Line != tt.line || fe.Field != tt.field {
+				t.Errorf("error at line %d, field %q, want line %d, field %q (%v)", fe.Line, fe.Field, tt.line, tt.field, err)
+			}
+			if !strings.Contains(err.Error(), tt.errHas) {
+				t.Errorf("error %q does not mention %s", err, tt.errHas)
+			}
+		})
+	}
+}
+
+func TestForAccount(t *testing.T) {
+	cfg, err := ParseConfig([]byte(`regions: [KR]
+workers: 4
+servers:
+  exclude: [keep-me]
+groups:
+  lab:
+    workers: 2
+    login_keys:
+      include: [lab-key]
+accounts:
+  Student-01:
+    regions: [SGN]
+    servers:
+      include: [web]
+`), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := cfg.ForAccount(ncp.RootAccount{AccountName: "Student-01", Group: "LAB"})
+	if c.Workers != 2 || strings.Join(c.Regions, ",") != "SGN" {
+		t.Errorf("workers %d, regions %v, want 2 and [SGN]", c.Workers, c.Regions)
+	}
+	if f := c.Filters["servers"]; len(f.Exclude) != 0 || len(f.Include) != 1 {
+		t.Errorf("servers filter %+v, want the account's own", f)
+	}
+	if len(c.Filters["login_keys"].Include) != 1 {
+		t.Error("group filter not merged")
+	}
+	if len(cfg.Filters["login_keys"].Include) != 0 {
+		t.Error("ForAccount changed the base config")
+	}
+
+	if c := cfg.ForAccount(ncp.RootAccount{AccountName: "Student-02"}); c.Workers != 4 {
+		t.Errorf("unlisted account: workers %d, want 4", c.Workers)
+	}
+}
+
+func TestOverrides(t *testing.T) {
+	cfg, err := ParseConfig([]byte(`max_passes: 5
+accounts:
+  Student-01:
+    regions: [SGN]
+    workers: 2
+`), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg = Overrides{Regions: []string{"KR"}, OlderThan: Duration(time.Hour)}.Apply(cfg)
+
+	c := cfg.ForAccount(ncp.RootAccount{AccountName: "Student-01"})
+	if strings.Join(c.Regions, ",") != "KR" {
+		t.Errorf("regions %v, want the override over the account section", c.Regions)
+	}
+	if c.Workers != 2 || c.MaxPasses != 5 || time.Duration(c.OlderThan) != time.Hour {
+		t.Errorf("workers %d, max passes %d, older than %v, want 2, 5, 1h", c.Workers, c.MaxPasses, c.OlderThan)
+	}
+
+	if got := (Overrides{}).Apply(nil); got != nil {
+		t.Errorf("zero overrides on nil = %+v, want nil", got)
+	}
+	if got := (Overrides{Workers: 3}).Apply(nil); got == nil || got.Workers != 3 {
+		t.Errorf("overrides on nil = %+v, want workers 3", got)
+	}
+}
+
+func TestCheckAccount(t *testing.T) {
+	cfg := &Config{AccountGuard: &AccountGuard{Allow: []string{"111", "222"}, Deny: []string{"222"}}}
+	if err := CheckAccount(cfg, "111"); err != nil {
+		t.Errorf("allowed: %v", err)
+	}
+	for _, no := range []string{"222", "333", ""} {
+		if err := CheckAccount(cfg, no); err == nil {
+			t.Errorf("member %q: want an error", no)
+		}
+	}
+	// An unknown member number says where the number comes from.
+	if err := CheckAccount(cfg, ""); err == nil || !strings.Contains(err.Error(), "계약 내역") {
+		t.Errorf("unknown member: err = %v, want the billing contracts named", err)
+	}
+	if err := CheckAccount(nil, ""); err != nil {
+		t.Errorf("no guard: %v", err)
+	}
+}
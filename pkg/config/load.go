@@ -0,0 +1,346 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"ncp-nuke/pkg/ncp"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FieldError is an error in a config file, at the field on the given line.
+type FieldError struct {
+	Line  int
+	Field string // dotted path, e.g. "servers.exclude[2]"
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("%d번째 줄: %v", e.Line, e.Err)
+	}
+	return fmt.Sprintf("%d번째 줄, %s: %v", e.Line, e.Field, e.Err)
+}
+
+func (e *FieldError) Unwrap() error { return e.Err }
+
+// IsYAML reports whether path names a YAML config file (.yaml or .yml);
+// any other file is read as JSON.
+func IsYAML(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return true
+	}
+	return false
+}
+
+// ParseConfig decodes a JSON or YAML config strictly: unknown fields, values
+// of the wrong type and invalid patterns, conditions and durations are
+// errors, reported as *FieldError where the line is known.
+func ParseConfig(data []byte, isYAML bool) (*Config, error) {
+	if !isYAML {
+		// Parse JSON as YAML, a superset of it, for the line numbers. Check
+		// the syntax first for JSON's own error; tabs can only be whitespace
+		// in valid JSON and YAML does not indent with them.
+		var v any
+		if err := json.Unmarshal(data, &v); err != nil {
+			var se *json.SyntaxError
+			if errors.As(err, &se) {
+				return nil, &FieldError{Line: lineAt(data, se.Offset), Err: err}
+			}
+			return nil, err
+		}
+		data = bytes.ReplaceAll(data, []byte("\t"), []byte(" "))
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if doc.Kind == 0 {
+		return &Config{}, nil // empty file
+	}
+	root := &doc
+	if root.Kind == yaml.DocumentNode {
+		root = root.Content[0]
+	}
+	if err := checkNode(root, configType, ""); err != nil {
+		return nil, err
+	}
+	var cfg Config
+	if err := decodeNode(root, &cfg); err != nil {
+		return nil, &FieldError{Line: root.Line, Err: err}
+	}
+	return &cfg, nil
+}
+
+// lineAt returns the line of the byte offset in data.
+func lineAt(data []byte, offset int64) int {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
+
+// decodeNode decodes n into v through JSON, so that both formats share the
+// JSON decoding of the config types (per-type filter keys, Duration, ...).
+func decodeNode(n *yaml.Node, v any) error {
+	var x any
+	if err := n.Decode(&x); err != nil {
+		return err
+	}
+	data, err := json.Marshal(x)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+var (
+	configType    = reflect.TypeOf(Config{})
+	filterType    = reflect.TypeOf(ResourceFilter{})
+	unmarshalType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	validatorType = reflect.TypeOf((*interface{ Validate() error })(nil)).Elem()
+)
+
+// checkNode checks n against the JSON form of t: every mapping key must be a
+// field of the struct (or, for Config, a resource type key), and every value
+// must decode into its field and pass its Validate method.
+func checkNode(n *yaml.Node, t reflect.Type, field string) error {
+	fail := func(n *yaml.Node, field string, err error) error {
+		return &FieldError{Line: n.Line, Field: field, Err: err}
+	}
+	if n.Kind == yaml.AliasNode {
+		n = n.Alias
+	}
+	if n.ShortTag() == "!!null" {
+		return nil // same as leaving the field out
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t.Kind() == reflect.Struct && !reflect.PointerTo(t).Implements(unmarshalType) || t == configType:
+		if n.Kind != yaml.MappingNode {
+			return fail(n, field, fmt.Errorf("항목 목록(key: value)이어야 합니다"))
+		}
+		fields := jsonFields(t)
+		if err := checkDuplicates(n, field); err != nil {
+			return err
+		}
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			k, v := n.Content[i], n.Content[i+1]
+			sub := joinField(field, k.Value)
+			ft, ok := fields[k.Value]
+			if !ok {
+				return fail(k, sub, unknownField(k.Value, fields))
+			}
+			if err := checkNode(v, ft, sub); err != nil {
+				return err
+			}
+			if check := entryChecks[k.Value]; t == filterType && check != nil && v.Kind == yaml.SequenceNode {
+				for j, e := range v.Content {
+					if err := check(e.Value); err != nil {
+						return fail(e, fmt.Sprintf("%s[%d]", sub, j), err)
+					}
+				}
+			}
+			if t == configType {
+				if err := checkSetting(k.Value, v); err != nil {
+					return fail(v, sub, err)
+				}
+			}
+		}
+		if t == configType {
+			// The age pair and nested sections span several fields.
+			var c Config
+			if err := decodeNode(n, &c); err != nil {
+				return fail(n, field, err)
+			}
+			age := ResourceFilter{OlderThan: c.OlderThan, NewerThan: c.NewerThan}
+			if err := age.Validate(); err != nil {
+				return fail(n, joinField(field, "older_than"), err)
+			}
+			if field != "" {
+				if err := c.validateSection(); err != nil {
+					return fail(n, field, err)
+				}
+			}
+		}
+		if t != configType && reflect.PointerTo(t).Implements(validatorType) {
+			v := reflect.New(t)
+			if err := decodeNode(n, v.Interface()); err != nil {
+				return fail(n, field, err)
+			}
+			if err := v.Interface().(interface{ Validate() error }).Validate(); err != nil {
+				return fail(n, field, err)
+			}
+		}
+		return nil
+
+	case t.Kind() == reflect.Slice && n.Kind == yaml.SequenceNode:
+		for i, e := range n.Content {
+			if err := checkNode(e, t.Elem(), fmt.Sprintf("%s[%d]", field, i)); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case t.Kind() == reflect.Map && n.Kind == yaml.MappingNode:
+		if err := checkDuplicates(n, field); err != nil {
+			return err
+		}
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			k, v := n.Content[i], n.Content[i+1]
+			if err := checkNode(v, t.Elem(), joinField(field, k.Value)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// A leaf value: it must decode into t and pass its checks.
+	v := reflect.New(t)
+	if err := decodeNode(n, v.Interface()); err != nil {
+		var te *json.UnmarshalTypeError
+		if errors.As(err, &te) {
+			err = fmt.Errorf("%s 값이어야 합니다 (%s)", typeName(t), n.Value)
+		}
+		return fail(n, field, err)
+	}
+	if v.Type().Implements(validatorType) {
+		if err := v.Interface().(interface{ Validate() error }).Validate(); err != nil {
+			return fail(n, field, err)
+		}
+	}
+	return nil
+}
+
+// entryChecks validate the list entries of the filter fields, so that an
+// error points at the entry.
+var entryChecks = map[string]func(string) error{
+	"include":      ValidatePattern,
+	"exclude":      ValidatePattern,
+	"include_tags": ValidateTag,
+	"exclude_tags": ValidateTag,
+}
+
+// checkDuplicates rejects a key that appears twice in mapping n.
+func checkDuplicates(n *yaml.Node, field string) error {
+	seen := make(map[string]int)
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		k := n.Content[i]
+		if line, ok := seen[k.Value]; ok {
+			return &FieldError{Line: k.Line, Field: joinField(field, k.Value), Err: fmt.Errorf("중복된 항목입니다 (%d번째 줄에도 있음)", line)}
+		}
+		seen[k.Value] = k.Line
+	}
+	return nil
+}
+
+// checkSetting checks the top-level settings whose valid values are known.
+func checkSetting(key string, v *yaml.Node) error {
+	switch key {
+	case "site":
+		_, err := ncp.ParseSite(v.Value)
+		return err
+	case "platform":
+		_, err := ncp.ParsePlatform(v.Value)
+		return err
+	}
+	return nil
+}
+
+// jsonFields returns the JSON field names of struct t and their types. For
+// Config these are its settings and the resource type keys.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" || !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f.Type
+	}
+	if t == configType {
+		for _, rt := range ncp.ResourceTypes() {
+			fields[rt.Key()] = filterType
+		}
+	}
+	return fields
+}
+
+// unknownField is the error for key, suggesting the closest known field.
+func unknownField(key string, fields map[string]reflect.Type) error {
+	best, dist := "", len(key)/2+1
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if d := editDistance(key, name); d < dist {
+			best, dist = name, d
+		}
+	}
+	if best != "" {
+		return fmt.Errorf("알 수 없는 항목입니다 (%q 을(를) 의도했나요?)", best)
+	}
+	return fmt.Errorf("알 수 없는 항목입니다")
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+func joinField(parent, key string) string {
+	if parent == "" {
+		return key
+	}
+	return parent + "." + key
+}
+
+// typeName names the kind of value t holds, for error messages.
+func typeName(t reflect.Type) string {
+	switch {
+	case t == reflect.TypeOf(Duration(0)):
+		return "기간 문자열(예: \"72h\", \"7d\")"
+	case t.Kind() == reflect.String:
+		return "문자열"
+	case t.Kind() == reflect.Bool:
+		return "true/false"
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Float64:
+		return "숫자"
+	case t.Kind() == reflect.Slice:
+		return "목록"
+	}
+	return t.String()
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"ncp-nuke/pkg/ncp"
+)
+
+// Template returns a commented YAML config with every setting commented out
+// and an empty filter for every registered resource type, which deletes all
+// resources of that type.
+func Template() string {
+	var b strings.Builder
+	b.WriteString(`# ncp-nuke 설정 파일
+# 검사: ncp-nuke config validate <파일>
+#
+# 리소스 종류별 필터 (아래의 각 키, 비워 두면 모두 삭제):
+#   enabled: false                  이 종류는 삭제하지 않음
+#   include: [이름 또는 ID, ...]     지정한 리소스만 삭제
+#   exclude: [이름 또는 ID, ...]     지정한 리소스는 삭제하지 않음
+#                                   (패턴: "glob:prod-*", "regex:-backup$", "prefix:shared-", "exact:...")
+#   include_tags / exclude_tags: ["key", "key=value"]
+#   where: [{field: status, op: eq, value: NSTOP}]
+#   older_than / newer_than: 72h, 7d (생성 후 경과 시간)
+
+`)
+	for _, l := range [][2]string{
+		{"regions: [KR]", "대상 리전, all: 전체 리전"},
+		{"site: public", "public, fin, gov (엑셀 Site 열이 비어 있는 계정)"},
+		{"platform: auto", "auto, vpc, classic, all (엑셀 Platform 열이 비어 있는 계정)"},
+		{fmt.Sprintf("workers: %d", ncp.DefaultWorkers), "계정·리전별 동시 삭제 수"},
+		{"max_passes: 3", "리전별 최대 삭제 패스 수"},
+		{"journal_dir: .", "작업 저널 디렉터리, \"-\": 기록 안 함"},
+		{"older_than: 7d", "모든 필터의 기본 기간 조건"},
+		{"newer_than: 30d", ""},
+		{"keep_tag: " + DefaultKeepTag, "이 태그가 붙은 리소스는 삭제하지 않음, \"-\": 끄기"},
+		{"vpc_scope: [lab-vpc]", "지정한 VPC와 그 안의 리소스만 대상"},
+		{"account_guard:", "회원 번호로 실행할 계정 제한 (Billing API로 확인)"},
+		{"  allow: [\"1234567\"]", "이 계정들만 실행"},
+		{"  deny: [\"7654321\"]", "이 계정들은 실행하지 않음"},
+		{"endpoints:", "서비스별 API 엔드포인트"},
+		{"  vpc: http://localhost:8080/vpc", ""},
+		{"api:", ""},
+		{"  max_attempts: 5", "429/5xx/네트워크 오류 시 총 시도 횟수"},
+		{"  rate_limit: 10", "액세스 키별 초당 요청 수"},
+		{"  rate_burst: 10", ""},
+		{"  page_size: 100", "목록 API 페이지 크기"},
+		{"groups:", "엑셀 Group 열별 설정"},
+		{"  dev:", ""},
+		{"    regions: [KR, SGN]", ""},
+		{"accounts:", "AccountName별 설정"},
+		{"  my-account:", ""},
+		{"    vpc_scope: [lab-vpc]", ""},
+	} {
+		if l[1] == "" {
+			fmt.Fprintf(&b, "# %s\n", l[0])
+			continue
+		}
+		fmt.Fprintf(&b, "# %-32s # %s\n", l[0], l[1])
+	}
+	b.WriteString("\n")
+	for _, rt := range ncp.ResourceTypes() {
+		fmt.Fprintf(&b, "# %s\n%s: {}\n", rt.Name(), rt.Key())
+	}
+	return b.String()
+}
@@ -2,6 +2,7 @@ package excel
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
 	"ncp-nuke/pkg/ncp"
@@ -9,8 +10,12 @@ import (
 	"github.com/xuri/excelize/v2"
 )
 
-// ReadAccounts reads root account information from an Excel file.
+// ReadAccounts reads root account information from an Excel file. Rows it
+// skips are reported on stderr, keeping stdout for command output.
 // Expected columns: AccountName, AccessKey, SecretKey (first row is header).
+// An optional Site column (public / fin / gov) selects the NCP cloud site and
+// an optional Platform column (auto / vpc / classic / all) the platforms. An
+// optional Group column labels accounts for selection (e.g. --group lab).
 func ReadAccounts(filePath string) ([]ncp.RootAccount, error) {
 	f, err := excelize.OpenFile(filePath)
 	if err != nil {
@@ -55,14 +60,14 @@ func ReadAccounts(filePath string) ([]ncp.RootAccount, error) {
 		iamUsername := getCell(row, colIdx["iamusername"])
 
 		if accessKey == "" || secretKey == "" {
-			fmt.Printf("[WARN] Row %d: AccessKey or SecretKey is empty, skipping\n", lineNum)
+			fmt.Fprintf(os.Stderr, "[WARN] Row %d: AccessKey or SecretKey is empty, skipping\n", lineNum)
 			continue
 		}
 
 		// IAM Username is mandatory so that operations always target a specific
 		// sub account instead of every sub account under the root account.
 		if iamUsername == "" {
-			fmt.Printf("[WARN] Row %d: IAM Username is empty, skipping\n", lineNum)
+			fmt.Fprintf(os.Stderr, "[WARN] Row %d: IAM Username is empty, skipping\n", lineNum)
 			continue
 		}
 
@@ -79,12 +84,34 @@ func ReadAccounts(filePath string) ([]ncp.RootAccount, error) {
 			password = getCell(row, colIdx["password"])
 		}
 
+		var site ncp.Site
+		if v := getCell(row, colIdx["site"]); v != "" {
+			s, err := ncp.ParseSite(v)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "[WARN] Row %d: %v, skipping\n", lineNum, err)
+				continue
+			}
+			site = s
+		}
+		var platform ncp.Platform
+		if v := getCell(row, colIdx["platform"]); v != "" {
+			p, err := ncp.ParsePlatform(v)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "[WARN] Row %d: %v, skipping\n", lineNum, err)
+				continue
+			}
+			platform = p
+		}
+
 		accounts = append(accounts, ncp.RootAccount{
 			AccountName: name,
 			AccessKey:   accessKey,
 			SecretKey:   secretKey,
 			IamUsername: iamUsername,
 			Password:    password,
+			Site:        site,
+			Platform:    platform,
+			Group:       getCell(row, colIdx["group"]),
 		})
 	}
 
@@ -111,6 +138,9 @@ func detectColumns(header []string) map[string]int {
 		"secretkey":   -1,
 		"iamusername": -1,
 		"password":    -1,
+		"site":        -1,
+		"platform":    -1,
+		"group":       -1,
 	}
 	for i, cell := range header {
 		normalized := strings.ToLower(strings.TrimSpace(cell))
@@ -129,6 +159,12 @@ func detectColumns(header []string) map[string]int {
 			colIdx["iamusername"] = i
 		case normalized == "password" || normalized == "pw" || normalized == "비밀번호" || normalized == "비번":
 			colIdx["password"] = i
+		case normalized == "site" || normalized == "사이트":
+			colIdx["site"] = i
+		case normalized == "platform" || normalized == "플랫폼":
+			colIdx["platform"] = i
+		case normalized == "group" || normalized == "그룹":
+			colIdx["group"] = i
 		}
 	}
 	return colIdx
@@ -7,11 +7,11 @@ import (
 )
 
 // templateHeaders are the columns of the accounts template, in order.
-var templateHeaders = []string{"AccountName", "AccessKey", "SecretKey", "IAM Username", "Password"}
+var templateHeaders = []string{"AccountName", "AccessKey", "SecretKey", "IAM Username", "Password", "Site", "Platform", "Group"}
 
 var templateSamples = [][]string{
-	{"Student-01", "YOUR_ACCESS_KEY_HERE_1", "YOUR_SECRET_KEY_HERE_1", "student-id-01", "InitialPassword123!"},
-	{"Student-02", "YOUR_ACCESS_KEY_HERE_2", "YOUR_SECRET_KEY_HERE_2", "student-id-02", "InitialPassword123!"},
+	{"Student-01", "YOUR_ACCESS_KEY_HERE_1", "YOUR_SECRET_KEY_HERE_1", "student-id-01", "InitialPassword123!", "public", "auto", "lab"},
+	{"Student-02", "YOUR_ACCESS_KEY_HERE_2", "YOUR_SECRET_KEY_HERE_2", "student-id-02", "InitialPassword123!", "public", "auto", "lab"},
 }
 
 // buildTemplateFile returns a new accounts template workbook.
@@ -31,6 +31,7 @@ func buildTemplateFile() (*excelize.File, error) {
 		}
 	}
 	f.SetColWidth(sheet, "A", "E", 30)
+	f.SetColWidth(sheet, "F", "H", 12)
 	return f, nil
 }
 
@@ -43,6 +43,31 @@ func AppendAccount(filePath string, acc ncp.RootAccount) error {
 		return fmt.Errorf("header must contain AccessKey and SecretKey columns")
 	}
 
+	// Files created before the Site/Platform/Group columns existed get them
+	// appended to the header when the new account needs a non-default value.
+	next := len(rows[0])
+	for _, col := range []struct {
+		field, header string
+		needed        bool
+	}{
+		{"site", "Site", acc.Site != "" && acc.Site != ncp.SitePublic},
+		{"platform", "Platform", acc.Platform != "" && acc.Platform != ncp.PlatformAuto},
+		{"group", "Group", acc.Group != ""},
+	} {
+		if colIdx[col.field] != -1 || !col.needed {
+			continue
+		}
+		cell, err := excelize.CoordinatesToCellName(next+1, 1)
+		if err != nil {
+			return err
+		}
+		if err := f.SetCellValue(sheet, cell, col.header); err != nil {
+			return fmt.Errorf("writing %s header: %w", col.field, err)
+		}
+		colIdx[col.field] = next
+		next++
+	}
+
 	newRow := len(rows) + 1 // 1-based; append after the last existing row
 
 	set := func(field, value string) error {
@@ -63,6 +88,9 @@ func AppendAccount(filePath string, acc ncp.RootAccount) error {
 		"secretkey":   acc.SecretKey,
 		"iamusername": acc.IamUsername,
 		"password":    acc.Password,
+		"site":        string(acc.Site),
+		"platform":    string(acc.Platform),
+		"group":       acc.Group,
 	} {
 		if err := set(field, value); err != nil {
 			return fmt.Errorf("writing %s: %w", field, err)
@@ -0,0 +1,277 @@
+// Package journal records every operation of a run in an append-only file
+// (one JSON record per line), so an interrupted run can be resumed: what was
+// finished is skipped and what was in flight is re-verified.
+package journal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Kind is the kind of a journal record.
+type Kind string
+
+const (
+	KindAccount    Kind = "account"     // an account's work starts
+	KindOperation  Kind = "op"          // one attempted operation (before and after)
+	KindAccountEnd Kind = "account_end" // an account's work finished
+	KindResume     Kind = "resume"      // the journal is being resumed
+)
+
+// Status of an operation record.
+const (
+	StatusRequested = "requested" // the API call is about to be made
+	StatusOK        = "ok"
+	StatusFailed    = "failed"
+	StatusVerified  = "verified" // an in-flight deletion was found complete on resume
+)
+
+// SubAccountType is the Type of sub-account operations.
+const SubAccountType = "sub_account"
+
+// Record is one line of the journal.
+type Record struct {
+	Time    time.Time `json:"time"`
+	Kind    Kind      `json:"kind"`
+	Account string    `json:"account,omitempty"`
+
+	// Account records: what the run does with the account.
+	Fingerprint string          `json:"fingerprint,omitempty"`
+	Action      string          `json:"action,omitempty"`
+	Cleanup     bool            `json:"cleanup,omitempty"`
+	Password    bool            `json:"password,omitempty"` // a common password was given (not stored)
+	Config      json.RawMessage `json:"config,omitempty"`
+
+	// Operation records.
+	Region string `json:"region,omitempty"`
+	Type   string `json:"type,omitempty"` // resource type config key, or SubAccountType
+	ID     string `json:"id,omitempty"`
+	Name   string `json:"name,omitempty"`
+	Op     string `json:"op,omitempty"` // delete, activate, deactivate
+	Status string `json:"status,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// opKey identifies the target of an operation record.
+func (r Record) opKey() string {
+	return r.Account + "\x00" + r.Region + "\x00" + r.Type + "\x00" + r.ID + "\x00" + r.Name + "\x00" + r.Op
+}
+
+// Writer appends records to a journal file. A nil *Writer discards records,
+// so callers can pass one around unconditionally.
+type Writer struct {
+	mu   sync.Mutex
+	f    *os.File
+	path string
+	done map[string]bool // opKey of operations that succeeded
+	err  error           // first write error; the journal is incomplete after it
+}
+
+// DefaultName is the file name for the n-th journal started at t (n from 1),
+// unique within the second the names have.
+func DefaultName(t time.Time, n int) string {
+	name := "ncp-nuke-" + t.Format("20060102-150405")
+	if n > 1 {
+		name += fmt.Sprintf("-%d", n)
+	}
+	return name + ".journal"
+}
+
+// Create starts a new journal in dir (the current directory if empty). Runs
+// started within the same second get numbered names.
+func Create(dir string) (*Writer, error) {
+	now := time.Now()
+	for n := 1; ; n++ {
+		path := filepath.Join(dir, DefaultName(now, n))
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL|os.O_APPEND, 0o600)
+		if os.IsExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("작업 저널 생성 실패: %w", err)
+		}
+		return &Writer{f: f, path: path, done: map[string]bool{}}, nil
+	}
+}
+
+// Open reopens an existing journal for appending and returns its records. A
+// truncated last line is cut off so new records start on a line of their own.
+func Open(path string) (*Writer, []Record, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	records, n, err := parse(path, data)
+	if err != nil {
+		return nil, nil, err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, nil, err
+	}
+	if n < len(data) {
+		if err := f.Truncate(int64(n)); err != nil {
+			f.Close()
+			return nil, nil, err
+		}
+	}
+	w := &Writer{f: f, path: path, done: map[string]bool{}}
+	for _, r := range records {
+		if r.Kind == KindOperation && (r.Status == StatusOK || r.Status == StatusVerified) {
+			w.done[r.opKey()] = true
+		}
+	}
+	return w, records, nil
+}
+
+// Read returns the records of a journal. A truncated last line (the process
+// died mid-write) is ignored.
+func Read(path string) ([]Record, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	records, _, err := parse(path, data)
+	return records, err
+}
+
+// parse decodes the records of data and returns how many bytes of it are
+// complete lines.
+func parse(path string, data []byte) ([]Record, int, error) {
+	var records []Record
+	n := 0
+	for line := 1; n < len(data); line++ {
+		end := bytes.IndexByte(data[n:], '\n')
+		if end < 0 {
+			break // last line cut off
+		}
+		text := data[n : n+end]
+		n += end + 1
+		if len(bytes.TrimSpace(text)) == 0 {
+			continue
+		}
+		var r Record
+		if err := json.Unmarshal(text, &r); err != nil {
+			return nil, 0, fmt.Errorf("%s:%d: 저널 레코드 해석 실패: %w", path, line, err)
+		}
+		records = append(records, r)
+	}
+	return records, n, nil
+}
+
+// Path returns the journal file path ("" for a nil Writer).
+func (w *Writer) Path() string {
+	if w == nil {
+		return ""
+	}
+	return w.path
+}
+
+// Append writes r (stamped with the current time) and syncs it to disk, so
+// the record survives the process being killed right after. Once a write has
+// failed every later Append returns that error.
+func (w *Writer) Append(r Record) error {
+	if w == nil {
+		return nil
+	}
+	r.Time = time.Now().UTC()
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.err != nil {
+		return w.err
+	}
+	if r.Kind == KindOperation && r.Status == StatusOK {
+		w.done[r.opKey()] = true
+	}
+	if _, err := w.f.Write(append(data, '\n')); err != nil {
+		w.err = err
+		return err
+	}
+	if err := w.f.Sync(); err != nil {
+		w.err = err
+	}
+	return w.err
+}
+
+// Succeeded reports whether the journal already holds a successful record of
+// op (matched on account, region, type, id, name and op).
+func (w *Writer) Succeeded(op Record) bool {
+	if w == nil {
+		return false
+	}
+	op.Kind = KindOperation
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.done[op.opKey()]
+}
+
+// Close closes the journal file.
+func (w *Writer) Close() error {
+	if w == nil {
+		return nil
+	}
+	return w.f.Close()
+}
+
+// Run is what a journal says about one action on one account.
+type Run struct {
+	Start    Record   // the latest account record of the action
+	Done     bool     // an account_end record follows Start
+	InFlight []Record // operations requested without a recorded result
+}
+
+// Runs folds records into one Run per account and action, in the order they
+// first appear. Operations belong to the account's latest started run.
+func Runs(records []Record) []*Run {
+	var order []*Run
+	byKey := map[string]*Run{}   // account + action -> run
+	current := map[string]*Run{} // account -> its latest started run
+	pending := map[*Run][]Record{}
+	for _, r := range records {
+		switch r.Kind {
+		case KindAccount:
+			key := r.Account + "\x00" + r.Action
+			run, ok := byKey[key]
+			if !ok {
+				run = &Run{}
+				byKey[key] = run
+				order = append(order, run)
+			}
+			run.Start, run.Done = r, false
+			current[r.Account] = run
+		case KindAccountEnd:
+			if run, ok := byKey[r.Account+"\x00"+r.Action]; ok {
+				run.Done = true
+			}
+		case KindOperation:
+			run, ok := current[r.Account]
+			if !ok {
+				continue
+			}
+			if r.Status == StatusRequested {
+				pending[run] = append(pending[run], r)
+				continue
+			}
+			ops := pending[run]
+			for i := range ops {
+				if ops[i].opKey() == r.opKey() {
+					pending[run] = append(ops[:i], ops[i+1:]...)
+					break
+				}
+			}
+		}
+	}
+	for _, run := range order {
+		run.InFlight = pending[run]
+	}
+	return order
+}
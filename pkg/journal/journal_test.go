@@ -0,0 +1,107 @@
+package journal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCreateUniqueNames(t *testing.T) {
+	dir := t.TempDir()
+	seen := map[string]bool{}
+	for i := 0; i < 3; i++ {
+		w, err := Create(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer w.Close()
+		if seen[w.Path()] {
+			t.Fatalf("journal %s created twice", w.Path())
+		}
+		seen[w.Path()] = true
+	}
+}
+
+func TestResume(t *testing.T) {
+	dir := t.TempDir()
+	w, err := Create(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	del := func(id, status string) Record {
+		return Record{Kind: KindOperation, Account: "a", Region: "KR", Type: "servers", ID: id, Op: "delete", Status: status}
+	}
+	for _, r := range []Record{
+		{Kind: KindAccount, Account: "a", Action: "nuke"},
+		del("1", StatusRequested), del("1", StatusOK),
+		del("2", StatusRequested), del("2", StatusFailed),
+		del("3", StatusRequested),
+		{Kind: KindAccount, Account: "b", Action: "nuke"},
+		{Kind: KindAccountEnd, Account: "b", Action: "nuke"},
+	} {
+		if err := w.Append(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	w.Close()
+
+	// The process died mid-write.
+	f, err := os.OpenFile(w.Path(), os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"kind":"op","acc`)
+	f.Close()
+
+	w, records, err := Open(w.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 8 {
+		t.Fatalf("%d records, want 8", len(records))
+	}
+	if !w.Succeeded(del("1", "")) || w.Succeeded(del("2", "")) || w.Succeeded(del("3", "")) {
+		t.Error("Succeeded: want only the deletion of 1")
+	}
+
+	runs := Runs(records)
+	if len(runs) != 2 {
+		t.Fatalf("%d runs, want 2", len(runs))
+	}
+	if runs[0].Done || len(runs[0].InFlight) != 1 || runs[0].InFlight[0].ID != "3" {
+		t.Errorf("run of a: done %v, in flight %v, want not done with 3 in flight", runs[0].Done, runs[0].InFlight)
+	}
+	if !runs[1].Done {
+		t.Error("run of b not done")
+	}
+
+	// New records start on a line of their own.
+	if err := w.Append(Record{Kind: KindResume}); err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+	records, err = Read(w.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 9 || records[8].Kind != KindResume {
+		t.Errorf("after resume: %d records, want 9 ending with the resume", len(records))
+	}
+}
+
+func TestReadCorrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bad.journal")
+	if err := os.WriteFile(path, []byte("{\"kind\":\"account\"}\nnot json\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Read(path); err == nil {
+		t.Error("corrupt line: want an error")
+	}
+}
+
+func TestNilWriter(t *testing.T) {
+	var w *Writer
+	if err := w.Append(Record{Kind: KindAccount}); err != nil || w.Succeeded(Record{}) || w.Path() != "" || w.Close() != nil {
+		t.Error("a nil Writer must discard records")
+	}
+}
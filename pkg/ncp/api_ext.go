@@ -1,246 +1,151 @@
 package ncp
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/url"
 )
 
-// --- Cloud DB APIs ---
+// --- Region APIs ---
 
-func (c *Client) ListCloudDBInstances() ([]CloudDBInstance, error) {
-	path := "/getCloudDBInstanceList?responseFormatType=json"
-	body, status, err := c.doRequestWithBase(VCloudDBBaseURL, "GET", path, nil)
-	if err != nil {
-		return nil, err
-	}
-	if status != 200 {
-		return nil, fmt.Errorf("HTTP %d - %s", status, string(body))
-	}
+// ListRegions returns the regions available to the account (getRegionList).
+func (c *Client) ListRegions(ctx context.Context) ([]Region, error) {
+	return listAll[Region](ctx, c, ServiceVServer, "getRegionList", "regionList", nil)
+}
 
-	var resp struct {
-		Response getCloudDBInstanceListResponse `json:"getCloudDBInstanceListResponse"`
-	}
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("parsing response: %w", err)
-	}
-	return resp.Response.CloudDBInstanceList, nil
+// --- Cloud DB APIs ---
+
+func (c *Client) ListCloudDBInstances(ctx context.Context) ([]CloudDBInstance, error) {
+	return listAll[CloudDBInstance](ctx, c, ServiceCloudDB, "getCloudDBInstanceList", "cloudDBInstanceList", nil)
 }
 
-func (c *Client) DeleteCloudDBInstance(instanceNo string) error {
+func (c *Client) DeleteCloudDBInstance(ctx context.Context, instanceNo string) error {
 	params := url.Values{}
 	params.Set("responseFormatType", "json")
 	params.Set("cloudDBInstanceNo", instanceNo)
 	path := "/deleteCloudDBServerInstance?" + params.Encode() // Note: API name is ServerInstance but acts on Instance
-	body, status, err := c.doRequestWithBase(VCloudDBBaseURL, "GET", path, nil)
+	body, status, err := c.doService(ctx, ServiceCloudDB, "GET", path, nil)
 	if err != nil {
 		return err
 	}
 	if status != 200 {
-		return fmt.Errorf("HTTP %d - %s", status, string(body))
+		return newAPIError(ServiceCloudDB, "GET", path, status, body)
 	}
 	return nil
 }
 
 // --- Cloud DB for PostgreSQL APIs ---
 
-func (c *Client) ListCloudPostgresqlInstances() ([]CloudPostgresqlInstance, error) {
-	path := "/getCloudPostgresqlInstanceList?responseFormatType=json"
-	body, status, err := c.doRequestWithBase(VPostgreSQLBaseURL, "GET", path, nil)
-	if err != nil {
-		return nil, err
-	}
-	if status != 200 {
-		return nil, fmt.Errorf("HTTP %d - %s", status, string(body))
-	}
-
-	var resp struct {
-		Response getCloudPostgresqlInstanceListResponse `json:"getCloudPostgresqlInstanceListResponse"`
-	}
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("parsing response: %w", err)
-	}
-	return resp.Response.CloudPostgresqlInstanceList, nil
+func (c *Client) ListCloudPostgresqlInstances(ctx context.Context) ([]CloudPostgresqlInstance, error) {
+	return listAll[CloudPostgresqlInstance](ctx, c, ServiceVPostgreSQL, "getCloudPostgresqlInstanceList", "cloudPostgresqlInstanceList", nil)
 }
 
-func (c *Client) DeleteCloudPostgresqlInstance(instanceNo string) error {
+func (c *Client) DeleteCloudPostgresqlInstance(ctx context.Context, instanceNo string) error {
 	params := url.Values{}
 	params.Set("responseFormatType", "json")
 	params.Set("cloudPostgresqlInstanceNo", instanceNo)
 	path := "/deleteCloudPostgresqlInstance?" + params.Encode()
-	body, status, err := c.doRequestWithBase(VPostgreSQLBaseURL, "GET", path, nil)
+	body, status, err := c.doService(ctx, ServiceVPostgreSQL, "GET", path, nil)
 	if err != nil {
 		return err
 	}
 	if status != 200 {
-		return fmt.Errorf("HTTP %d - %s", status, string(body))
+		return newAPIError(ServiceVPostgreSQL, "GET", path, status, body)
 	}
 	return nil
 }
 
 // --- Cloud DB for MongoDB APIs ---
 
-func (c *Client) ListCloudMongoDBInstances() ([]CloudMongoDbInstance, error) {
-	path := "/getCloudMongoDbInstanceList?responseFormatType=json"
-	body, status, err := c.doRequestWithBase(VMongoDBBaseURL, "GET", path, nil)
-	if err != nil {
-		return nil, err
-	}
-	if status != 200 {
-		return nil, fmt.Errorf("HTTP %d - %s", status, string(body))
-	}
-
-	var resp struct {
-		Response getCloudMongoDbInstanceListResponse `json:"getCloudMongoDbInstanceListResponse"`
-	}
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("parsing response: %w", err)
-	}
-	return resp.Response.CloudMongoDbInstanceList, nil
+func (c *Client) ListCloudMongoDBInstances(ctx context.Context) ([]CloudMongoDbInstance, error) {
+	return listAll[CloudMongoDbInstance](ctx, c, ServiceVMongoDB, "getCloudMongoDbInstanceList", "cloudMongoDbInstanceList", nil)
 }
 
-func (c *Client) DeleteCloudMongoDBInstance(instanceNo string) error {
+func (c *Client) DeleteCloudMongoDBInstance(ctx context.Context, instanceNo string) error {
 	params := url.Values{}
 	params.Set("responseFormatType", "json")
 	params.Set("cloudMongoDbInstanceNo", instanceNo)
 	path := "/deleteCloudMongoDbInstance?" + params.Encode()
-	body, status, err := c.doRequestWithBase(VMongoDBBaseURL, "GET", path, nil)
+	body, status, err := c.doService(ctx, ServiceVMongoDB, "GET", path, nil)
 	if err != nil {
 		return err
 	}
 	if status != 200 {
-		return fmt.Errorf("HTTP %d - %s", status, string(body))
+		return newAPIError(ServiceVMongoDB, "GET", path, status, body)
 	}
 	return nil
 }
 
 // --- VPC APIs ---
 
-func (c *Client) ListVpcs() ([]Vpc, error) {
-	path := "/getVpcList?responseFormatType=json"
-	body, status, err := c.doRequestWithBase(VVPCBaseURL, "GET", path, nil)
-	if err != nil {
-		return nil, err
-	}
-	if status != 200 {
-		return nil, fmt.Errorf("HTTP %d - %s", status, string(body))
-	}
-
-	var resp struct {
-		Response getVpcListResponse `json:"getVpcListResponse"`
-	}
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("parsing response: %w", err)
-	}
-	return resp.Response.VpcList, nil
+func (c *Client) ListVpcs(ctx context.Context) ([]Vpc, error) {
+	return listAll[Vpc](ctx, c, ServiceVPC, "getVpcList", "vpcList", nil)
 }
 
-func (c *Client) DeleteVpc(vpcNo string) error {
+func (c *Client) DeleteVpc(ctx context.Context, vpcNo string) error {
 	params := url.Values{}
 	params.Set("responseFormatType", "json")
 	params.Set("vpcNo", vpcNo)
 	path := "/deleteVpc?" + params.Encode()
-	body, status, err := c.doRequestWithBase(VVPCBaseURL, "GET", path, nil)
+	body, status, err := c.doService(ctx, ServiceVPC, "GET", path, nil)
 	if err != nil {
 		return err
 	}
 	if status != 200 {
-		return fmt.Errorf("HTTP %d - %s", status, string(body))
+		return newAPIError(ServiceVPC, "GET", path, status, body)
 	}
 	return nil
 }
 
-func (c *Client) ListSubnets() ([]Subnet, error) {
-	path := "/getSubnetList?responseFormatType=json"
-	body, status, err := c.doRequestWithBase(VVPCBaseURL, "GET", path, nil)
-	if err != nil {
-		return nil, err
-	}
-	if status != 200 {
-		return nil, fmt.Errorf("HTTP %d - %s", status, string(body))
-	}
-
-	var resp struct {
-		Response getSubnetListResponse `json:"getSubnetListResponse"`
-	}
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("parsing response: %w", err)
-	}
-	return resp.Response.SubnetList, nil
+func (c *Client) ListSubnets(ctx context.Context) ([]Subnet, error) {
+	return listAll[Subnet](ctx, c, ServiceVPC, "getSubnetList", "subnetList", nil)
 }
 
-func (c *Client) DeleteSubnet(subnetNo string) error {
+func (c *Client) DeleteSubnet(ctx context.Context, subnetNo string) error {
 	params := url.Values{}
 	params.Set("responseFormatType", "json")
 	params.Set("subnetNo", subnetNo)
 	path := "/deleteSubnet?" + params.Encode()
-	body, status, err := c.doRequestWithBase(VVPCBaseURL, "GET", path, nil)
+	body, status, err := c.doService(ctx, ServiceVPC, "GET", path, nil)
 	if err != nil {
 		return err
 	}
 	if status != 200 {
-		return fmt.Errorf("HTTP %d - %s", status, string(body))
+		return newAPIError(ServiceVPC, "GET", path, status, body)
 	}
 	return nil
 }
 
-func (c *Client) ListNatGateways() ([]NatGatewayInstance, error) {
-	path := "/getNatGatewayInstanceList?responseFormatType=json"
-	body, status, err := c.doRequestWithBase(VVPCBaseURL, "GET", path, nil)
-	if err != nil {
-		return nil, err
-	}
-	if status != 200 {
-		return nil, fmt.Errorf("HTTP %d - %s", status, string(body))
-	}
-
-	var resp struct {
-		Response getNatGatewayInstanceListResponse `json:"getNatGatewayInstanceListResponse"`
-	}
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("parsing response: %w", err)
-	}
-	return resp.Response.NatGatewayInstanceList, nil
+func (c *Client) ListNatGateways(ctx context.Context) ([]NatGatewayInstance, error) {
+	return listAll[NatGatewayInstance](ctx, c, ServiceVPC, "getNatGatewayInstanceList", "natGatewayInstanceList", nil)
 }
 
-func (c *Client) DeleteNatGateway(instanceNo string) error {
+func (c *Client) DeleteNatGateway(ctx context.Context, instanceNo string) error {
 	params := url.Values{}
 	params.Set("responseFormatType", "json")
 	params.Set("natGatewayInstanceNo", instanceNo)
 	path := "/deleteNatGatewayInstance?" + params.Encode()
-	body, status, err := c.doRequestWithBase(VVPCBaseURL, "GET", path, nil)
+	body, status, err := c.doService(ctx, ServiceVPC, "GET", path, nil)
 	if err != nil {
 		return err
 	}
 	if status != 200 {
-		return fmt.Errorf("HTTP %d - %s", status, string(body))
+		return newAPIError(ServiceVPC, "GET", path, status, body)
 	}
 	return nil
 }
 
-func (c *Client) ListRouteTables() ([]RouteTable, error) {
-	path := "/getRouteTableList?responseFormatType=json"
-	body, status, err := c.doRequestWithBase(VVPCBaseURL, "GET", path, nil)
+func (c *Client) ListRouteTables(ctx context.Context) ([]RouteTable, error) {
+	tables, err := listAll[RouteTable](ctx, c, ServiceVPC, "getRouteTableList", "routeTableList", nil)
 	if err != nil {
 		return nil, err
 	}
-	if status != 200 {
-		return nil, fmt.Errorf("HTTP %d - %s", status, string(body))
-	}
-
-	var resp struct {
-		Response getRouteTableListResponse `json:"getRouteTableListResponse"`
-	}
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("parsing response: %w", err)
-	}
 
 	// getRouteTableList does not include the routes themselves; fetch them per table
 	// so that NAT/Peering routes can be removed before deleting their targets.
-	tables := resp.Response.RouteTableList
 	for i := range tables {
-		routes, err := c.ListRoutes(tables[i].VpcNo, tables[i].RouteTableNo)
+		routes, err := c.ListRoutes(ctx, tables[i].VpcNo, tables[i].RouteTableNo)
 		if err != nil {
 			continue // best-effort: leave RouteList empty on failure
 		}
@@ -251,18 +156,18 @@ func (c *Client) ListRouteTables() ([]RouteTable, error) {
 
 // ListRoutes returns the routes within a route table.
 // The NCP VPC getRouteList API requires both vpcNo and routeTableNo.
-func (c *Client) ListRoutes(vpcNo, routeTableNo string) ([]Route, error) {
+func (c *Client) ListRoutes(ctx context.Context, vpcNo, routeTableNo string) ([]Route, error) {
 	params := url.Values{}
 	params.Set("responseFormatType", "json")
 	params.Set("vpcNo", vpcNo)
 	params.Set("routeTableNo", routeTableNo)
 	path := "/getRouteList?" + params.Encode()
-	body, status, err := c.doRequestWithBase(VVPCBaseURL, "GET", path, nil)
+	body, status, err := c.doService(ctx, ServiceVPC, "GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
 	if status != 200 {
-		return nil, fmt.Errorf("HTTP %d - %s", status, string(body))
+		return nil, newAPIError(ServiceVPC, "GET", path, status, body)
 	}
 	var resp struct {
 		Response struct {
@@ -277,7 +182,7 @@ func (c *Client) ListRoutes(vpcNo, routeTableNo string) ([]Route, error) {
 
 // RemoveRoute removes a specific route from a route table.
 // The NCP VPC removeRoute API requires vpcNo in addition to routeTableNo.
-func (c *Client) RemoveRoute(vpcNo, routeTableNo string, route Route) error {
+func (c *Client) RemoveRoute(ctx context.Context, vpcNo, routeTableNo string, route Route) error {
 	params := url.Values{}
 	params.Set("responseFormatType", "json")
 	params.Set("vpcNo", vpcNo)
@@ -288,78 +193,66 @@ func (c *Client) RemoveRoute(vpcNo, routeTableNo string, route Route) error {
 	params.Set("routeList.1.targetName", route.TargetName)
 
 	path := "/removeRoute?" + params.Encode()
-	body, status, err := c.doRequestWithBase(VVPCBaseURL, "GET", path, nil)
+	body, status, err := c.doService(ctx, ServiceVPC, "GET", path, nil)
 	if err != nil {
 		return err
 	}
 	if status != 200 {
-		return fmt.Errorf("HTTP %d - %s", status, string(body))
+		return newAPIError(ServiceVPC, "GET", path, status, body)
 	}
 	return nil
 }
 
-// --- ACG APIs ---
-
-func (c *Client) ListAccessControlGroups() ([]AccessControlGroup, error) {
-	path := "/getAccessControlGroupList?responseFormatType=json"
-	body, status, err := c.doRequestWithBase(VServerBaseURL, "GET", path, nil) // ACG is in vserver/v2
+// DeleteRouteTable deletes a non-default route table. Default tables are
+// deleted together with their VPC.
+func (c *Client) DeleteRouteTable(ctx context.Context, vpcNo, routeTableNo string) error {
+	params := url.Values{}
+	params.Set("responseFormatType", "json")
+	params.Set("vpcNo", vpcNo)
+	params.Set("routeTableNo", routeTableNo)
+	path := "/deleteRouteTable?" + params.Encode()
+	body, status, err := c.doService(ctx, ServiceVPC, "GET", path, nil)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	if status != 200 {
-		return nil, fmt.Errorf("HTTP %d - %s", status, string(body))
+		return newAPIError(ServiceVPC, "GET", path, status, body)
 	}
+	return nil
+}
 
-	var resp struct {
-		Response getAccessControlGroupListResponse `json:"getAccessControlGroupListResponse"`
-	}
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("parsing response: %w", err)
-	}
-	return resp.Response.AccessControlGroupList, nil
+// --- ACG APIs ---
+
+func (c *Client) ListAccessControlGroups(ctx context.Context) ([]AccessControlGroup, error) {
+	return listAll[AccessControlGroup](ctx, c, ServiceVServer, "getAccessControlGroupList", "accessControlGroupList", nil)
 }
 
-func (c *Client) DeleteAccessControlGroup(vpcNo, acgNo string) error {
+func (c *Client) DeleteAccessControlGroup(ctx context.Context, vpcNo, acgNo string) error {
 	params := url.Values{}
 	params.Set("responseFormatType", "json")
 	params.Set("vpcNo", vpcNo)
 	params.Set("accessControlGroupNo", acgNo)
 	path := "/deleteAccessControlGroup?" + params.Encode()
-	body, status, err := c.doRequestWithBase(VServerBaseURL, "GET", path, nil)
+	body, status, err := c.doService(ctx, ServiceVServer, "GET", path, nil)
 	if err != nil {
 		return err
 	}
 	if status != 200 {
-		return fmt.Errorf("HTTP %d - %s", status, string(body))
+		return newAPIError(ServiceVServer, "GET", path, status, body)
 	}
 	return nil
 }
 
 // --- Auto Scaling APIs ---
 
-func (c *Client) ListAutoScalingGroups() ([]AutoScalingGroup, error) {
-	path := "/getAutoScalingGroupList?responseFormatType=json"
-	body, status, err := c.doRequestWithBase(VAutoScalingBaseURL, "GET", path, nil)
-	if err != nil {
-		return nil, err
-	}
-	if status != 200 {
-		return nil, fmt.Errorf("HTTP %d - %s", status, string(body))
-	}
-
-	var resp struct {
-		Response getAutoScalingGroupListResponse `json:"getAutoScalingGroupListResponse"`
-	}
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("parsing response: %w", err)
-	}
-	return resp.Response.AutoScalingGroupList, nil
+func (c *Client) ListAutoScalingGroups(ctx context.Context) ([]AutoScalingGroup, error) {
+	return listAll[AutoScalingGroup](ctx, c, ServiceVAutoScaling, "getAutoScalingGroupList", "autoScalingGroupList", nil)
 }
 
 // SetAutoScalingGroupSizeZero sets an Auto Scaling Group's min/max/desired
 // capacity to 0 so its servers terminate and the group can then be deleted
 // (NCP rejects deleting a non-empty group, returnCode 1250600).
-func (c *Client) SetAutoScalingGroupSizeZero(groupNo string) error {
+func (c *Client) SetAutoScalingGroupSizeZero(ctx context.Context, groupNo string) error {
 	params := url.Values{}
 	params.Set("responseFormatType", "json")
 	params.Set("autoScalingGroupNo", groupNo)
@@ -367,41 +260,41 @@ func (c *Client) SetAutoScalingGroupSizeZero(groupNo string) error {
 	params.Set("maxSize", "0")
 	params.Set("desiredCapacity", "0")
 	path := "/updateAutoScalingGroup?" + params.Encode()
-	body, status, err := c.doRequestWithBase(VAutoScalingBaseURL, "GET", path, nil)
+	body, status, err := c.doService(ctx, ServiceVAutoScaling, "GET", path, nil)
 	if err != nil {
 		return err
 	}
 	if status != 200 {
-		return fmt.Errorf("HTTP %d - %s", status, string(body))
+		return newAPIError(ServiceVAutoScaling, "GET", path, status, body)
 	}
 	return nil
 }
 
-func (c *Client) DeleteAutoScalingGroup(groupNo string) error {
+func (c *Client) DeleteAutoScalingGroup(ctx context.Context, groupNo string) error {
 	params := url.Values{}
 	params.Set("responseFormatType", "json")
 	params.Set("autoScalingGroupNo", groupNo)
 	path := "/deleteAutoScalingGroup?" + params.Encode()
-	body, status, err := c.doRequestWithBase(VAutoScalingBaseURL, "GET", path, nil)
+	body, status, err := c.doService(ctx, ServiceVAutoScaling, "GET", path, nil)
 	if err != nil {
 		return err
 	}
 	if status != 200 {
-		return fmt.Errorf("HTTP %d - %s", status, string(body))
+		return newAPIError(ServiceVAutoScaling, "GET", path, status, body)
 	}
 	return nil
 }
 
 // --- NKS (Kubernetes) APIs ---
 
-func (c *Client) ListNksClusters() ([]NksCluster, error) {
+func (c *Client) ListNksClusters(ctx context.Context) ([]NksCluster, error) {
 	path := "/clusters"
-	body, status, err := c.doRequestWithBase(VNKSBaseURL, "GET", path, nil)
+	body, status, err := c.doService(ctx, ServiceVNKS, "GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
 	if status != 200 {
-		return nil, fmt.Errorf("HTTP %d - %s", status, string(body))
+		return nil, newAPIError(ServiceVNKS, "GET", path, status, body)
 	}
 
 	var resp getNksClusterListResponse
@@ -411,409 +304,255 @@ func (c *Client) ListNksClusters() ([]NksCluster, error) {
 	return resp.Clusters, nil
 }
 
-func (c *Client) DeleteNksCluster(uuid string) error {
+func (c *Client) DeleteNksCluster(ctx context.Context, uuid string) error {
 	path := "/clusters/" + uuid
-	body, status, err := c.doRequestWithBase(VNKSBaseURL, "DELETE", path, nil)
+	body, status, err := c.doService(ctx, ServiceVNKS, "DELETE", path, nil)
 	if err != nil {
 		return err
 	}
 	if status != 200 {
-		return fmt.Errorf("HTTP %d - %s", status, string(body))
+		return newAPIError(ServiceVNKS, "DELETE", path, status, body)
 	}
 	return nil
 }
 
 // --- Cloud DB for MariaDB APIs ---
 
-func (c *Client) ListCloudMariaDbInstances() ([]CloudMariaDbInstance, error) {
-	path := "/getCloudMariaDbInstanceList?responseFormatType=json"
-	body, status, err := c.doRequestWithBase(VMariaDBBaseURL, "GET", path, nil)
-	if err != nil {
-		return nil, err
-	}
-	if status != 200 {
-		return nil, fmt.Errorf("HTTP %d - %s", status, string(body))
-	}
-	var resp struct {
-		Response getCloudMariaDbInstanceListResponse `json:"getCloudMariaDbInstanceListResponse"`
-	}
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("parsing response: %w", err)
-	}
-	return resp.Response.CloudMariaDbInstanceList, nil
+func (c *Client) ListCloudMariaDbInstances(ctx context.Context) ([]CloudMariaDbInstance, error) {
+	return listAll[CloudMariaDbInstance](ctx, c, ServiceVMariaDB, "getCloudMariaDbInstanceList", "cloudMariaDbInstanceList", nil)
 }
 
-func (c *Client) DeleteCloudMariaDbInstance(instanceNo string) error {
+func (c *Client) DeleteCloudMariaDbInstance(ctx context.Context, instanceNo string) error {
 	params := url.Values{}
 	params.Set("responseFormatType", "json")
 	params.Set("cloudMariaDbInstanceNo", instanceNo)
 	path := "/deleteCloudMariaDbInstance?" + params.Encode()
-	body, status, err := c.doRequestWithBase(VMariaDBBaseURL, "GET", path, nil)
+	body, status, err := c.doService(ctx, ServiceVMariaDB, "GET", path, nil)
 	if err != nil {
 		return err
 	}
 	if status != 200 {
-		return fmt.Errorf("HTTP %d - %s", status, string(body))
+		return newAPIError(ServiceVMariaDB, "GET", path, status, body)
 	}
 	return nil
 }
 
 // --- Cloud DB for MySQL (dedicated) APIs ---
 
-func (c *Client) ListCloudMysqlInstances() ([]CloudMysqlInstance, error) {
-	path := "/getCloudMysqlInstanceList?responseFormatType=json"
-	body, status, err := c.doRequestWithBase(VMySQLBaseURL, "GET", path, nil)
-	if err != nil {
-		return nil, err
-	}
-	if status != 200 {
-		return nil, fmt.Errorf("HTTP %d - %s", status, string(body))
-	}
-	var resp struct {
-		Response getCloudMysqlInstanceListResponse `json:"getCloudMysqlInstanceListResponse"`
-	}
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("parsing response: %w", err)
-	}
-	return resp.Response.CloudMysqlInstanceList, nil
+func (c *Client) ListCloudMysqlInstances(ctx context.Context) ([]CloudMysqlInstance, error) {
+	return listAll[CloudMysqlInstance](ctx, c, ServiceVMySQL, "getCloudMysqlInstanceList", "cloudMysqlInstanceList", nil)
 }
 
-func (c *Client) DeleteCloudMysqlInstance(instanceNo string) error {
+func (c *Client) DeleteCloudMysqlInstance(ctx context.Context, instanceNo string) error {
 	params := url.Values{}
 	params.Set("responseFormatType", "json")
 	params.Set("cloudMysqlInstanceNo", instanceNo)
 	path := "/deleteCloudMysqlInstance?" + params.Encode()
-	body, status, err := c.doRequestWithBase(VMySQLBaseURL, "GET", path, nil)
+	body, status, err := c.doService(ctx, ServiceVMySQL, "GET", path, nil)
 	if err != nil {
 		return err
 	}
 	if status != 200 {
-		return fmt.Errorf("HTTP %d - %s", status, string(body))
+		return newAPIError(ServiceVMySQL, "GET", path, status, body)
 	}
 	return nil
 }
 
 // --- Cloud DB for Redis (dedicated) APIs ---
 
-func (c *Client) ListCloudRedisInstances() ([]CloudRedisInstance, error) {
-	path := "/getCloudRedisInstanceList?responseFormatType=json"
-	body, status, err := c.doRequestWithBase(VRedisBaseURL, "GET", path, nil)
-	if err != nil {
-		return nil, err
-	}
-	if status != 200 {
-		return nil, fmt.Errorf("HTTP %d - %s", status, string(body))
-	}
-	var resp struct {
-		Response getCloudRedisInstanceListResponse `json:"getCloudRedisInstanceListResponse"`
-	}
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("parsing response: %w", err)
-	}
-	return resp.Response.CloudRedisInstanceList, nil
+func (c *Client) ListCloudRedisInstances(ctx context.Context) ([]CloudRedisInstance, error) {
+	return listAll[CloudRedisInstance](ctx, c, ServiceVRedis, "getCloudRedisInstanceList", "cloudRedisInstanceList", nil)
 }
 
-func (c *Client) DeleteCloudRedisInstance(instanceNo string) error {
+func (c *Client) DeleteCloudRedisInstance(ctx context.Context, instanceNo string) error {
 	params := url.Values{}
 	params.Set("responseFormatType", "json")
 	params.Set("cloudRedisInstanceNo", instanceNo)
 	path := "/deleteCloudRedisInstance?" + params.Encode()
-	body, status, err := c.doRequestWithBase(VRedisBaseURL, "GET", path, nil)
+	body, status, err := c.doService(ctx, ServiceVRedis, "GET", path, nil)
 	if err != nil {
 		return err
 	}
 	if status != 200 {
-		return fmt.Errorf("HTTP %d - %s", status, string(body))
+		return newAPIError(ServiceVRedis, "GET", path, status, body)
 	}
 	return nil
 }
 
 // --- Launch Configuration APIs ---
 
-func (c *Client) ListLaunchConfigurations() ([]LaunchConfiguration, error) {
-	path := "/getLaunchConfigurationList?responseFormatType=json"
-	body, status, err := c.doRequestWithBase(VAutoScalingBaseURL, "GET", path, nil)
-	if err != nil {
-		return nil, err
-	}
-	if status != 200 {
-		return nil, fmt.Errorf("HTTP %d - %s", status, string(body))
-	}
-	var resp struct {
-		Response getLaunchConfigurationListResponse `json:"getLaunchConfigurationListResponse"`
-	}
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("parsing response: %w", err)
-	}
-	return resp.Response.LaunchConfigurationList, nil
+func (c *Client) ListLaunchConfigurations(ctx context.Context) ([]LaunchConfiguration, error) {
+	return listAll[LaunchConfiguration](ctx, c, ServiceVAutoScaling, "getLaunchConfigurationList", "launchConfigurationList", nil)
 }
 
-func (c *Client) DeleteLaunchConfiguration(launchConfigurationNo string) error {
+func (c *Client) DeleteLaunchConfiguration(ctx context.Context, launchConfigurationNo string) error {
 	params := url.Values{}
 	params.Set("responseFormatType", "json")
 	params.Set("launchConfigurationNo", launchConfigurationNo)
 	path := "/deleteLaunchConfiguration?" + params.Encode()
-	body, status, err := c.doRequestWithBase(VAutoScalingBaseURL, "GET", path, nil)
+	body, status, err := c.doService(ctx, ServiceVAutoScaling, "GET", path, nil)
 	if err != nil {
 		return err
 	}
 	if status != 200 {
-		return fmt.Errorf("HTTP %d - %s", status, string(body))
+		return newAPIError(ServiceVAutoScaling, "GET", path, status, body)
 	}
 	return nil
 }
 
 // --- Network ACL APIs ---
 
-func (c *Client) ListNetworkAcls() ([]NetworkAcl, error) {
-	path := "/getNetworkAclList?responseFormatType=json"
-	body, status, err := c.doRequestWithBase(VVPCBaseURL, "GET", path, nil)
-	if err != nil {
-		return nil, err
-	}
-	if status != 200 {
-		return nil, fmt.Errorf("HTTP %d - %s", status, string(body))
-	}
-	var resp struct {
-		Response getNetworkAclListResponse `json:"getNetworkAclListResponse"`
-	}
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("parsing response: %w", err)
-	}
-	return resp.Response.NetworkAclList, nil
+func (c *Client) ListNetworkAcls(ctx context.Context) ([]NetworkAcl, error) {
+	return listAll[NetworkAcl](ctx, c, ServiceVPC, "getNetworkAclList", "networkAclList", nil)
 }
 
-func (c *Client) DeleteNetworkAcl(networkAclNo string) error {
+func (c *Client) DeleteNetworkAcl(ctx context.Context, networkAclNo string) error {
 	params := url.Values{}
 	params.Set("responseFormatType", "json")
 	params.Set("networkAclNo", networkAclNo)
 	path := "/deleteNetworkAcl?" + params.Encode()
-	body, status, err := c.doRequestWithBase(VVPCBaseURL, "GET", path, nil)
+	body, status, err := c.doService(ctx, ServiceVPC, "GET", path, nil)
 	if err != nil {
 		return err
 	}
 	if status != 200 {
-		return fmt.Errorf("HTTP %d - %s", status, string(body))
+		return newAPIError(ServiceVPC, "GET", path, status, body)
 	}
 	return nil
 }
 
 // --- VPC Peering APIs ---
 
-func (c *Client) ListVpcPeeringInstances() ([]VpcPeeringInstance, error) {
-	path := "/getVpcPeeringInstanceList?responseFormatType=json"
-	body, status, err := c.doRequestWithBase(VVPCBaseURL, "GET", path, nil)
-	if err != nil {
-		return nil, err
-	}
-	if status != 200 {
-		return nil, fmt.Errorf("HTTP %d - %s", status, string(body))
-	}
-	var resp struct {
-		Response getVpcPeeringInstanceListResponse `json:"getVpcPeeringInstanceListResponse"`
-	}
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("parsing response: %w", err)
-	}
-	return resp.Response.VpcPeeringInstanceList, nil
+func (c *Client) ListVpcPeeringInstances(ctx context.Context) ([]VpcPeeringInstance, error) {
+	return listAll[VpcPeeringInstance](ctx, c, ServiceVPC, "getVpcPeeringInstanceList", "vpcPeeringInstanceList", nil)
 }
 
-func (c *Client) DeleteVpcPeeringInstance(vpcPeeringInstanceNo string) error {
+func (c *Client) DeleteVpcPeeringInstance(ctx context.Context, vpcPeeringInstanceNo string) error {
 	params := url.Values{}
 	params.Set("responseFormatType", "json")
 	params.Set("vpcPeeringInstanceNo", vpcPeeringInstanceNo)
 	path := "/deleteVpcPeeringInstance?" + params.Encode()
-	body, status, err := c.doRequestWithBase(VVPCBaseURL, "GET", path, nil)
+	body, status, err := c.doService(ctx, ServiceVPC, "GET", path, nil)
 	if err != nil {
 		return err
 	}
 	if status != 200 {
-		return fmt.Errorf("HTTP %d - %s", status, string(body))
+		return newAPIError(ServiceVPC, "GET", path, status, body)
 	}
 	return nil
 }
 
 // --- Init Script APIs ---
 
-func (c *Client) ListInitScripts() ([]InitScript, error) {
-	path := "/getInitScriptList?responseFormatType=json"
-	body, status, err := c.doRequestWithBase(VServerBaseURL, "GET", path, nil)
-	if err != nil {
-		return nil, err
-	}
-	if status != 200 {
-		return nil, fmt.Errorf("HTTP %d - %s", status, string(body))
-	}
-	var resp struct {
-		Response getInitScriptListResponse `json:"getInitScriptListResponse"`
-	}
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("parsing response: %w", err)
-	}
-	return resp.Response.InitScriptList, nil
+func (c *Client) ListInitScripts(ctx context.Context) ([]InitScript, error) {
+	return listAll[InitScript](ctx, c, ServiceVServer, "getInitScriptList", "initScriptList", nil)
 }
 
-func (c *Client) DeleteInitScripts(initScriptNos []string) error {
+func (c *Client) DeleteInitScripts(ctx context.Context, initScriptNos []string) error {
 	params := url.Values{}
 	params.Set("responseFormatType", "json")
 	for i, no := range initScriptNos {
 		params.Set(fmt.Sprintf("initScriptNoList.%d", i+1), no)
 	}
 	path := "/deleteInitScripts?" + params.Encode()
-	body, status, err := c.doRequestWithBase(VServerBaseURL, "GET", path, nil)
+	body, status, err := c.doService(ctx, ServiceVServer, "GET", path, nil)
 	if err != nil {
 		return err
 	}
 	if status != 200 {
-		return fmt.Errorf("HTTP %d - %s", status, string(body))
+		return newAPIError(ServiceVServer, "GET", path, status, body)
 	}
 	return nil
 }
 
 // --- Login Key APIs ---
 
-func (c *Client) ListLoginKeys() ([]LoginKey, error) {
-	path := "/getLoginKeyList?responseFormatType=json"
-	body, status, err := c.doRequestWithBase(VServerBaseURL, "GET", path, nil)
-	if err != nil {
-		return nil, err
-	}
-	if status != 200 {
-		return nil, fmt.Errorf("HTTP %d - %s", status, string(body))
-	}
-	var resp struct {
-		Response getLoginKeyListResponse `json:"getLoginKeyListResponse"`
-	}
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("parsing response: %w", err)
-	}
-	return resp.Response.LoginKeyList, nil
+func (c *Client) ListLoginKeys(ctx context.Context) ([]LoginKey, error) {
+	return listAll[LoginKey](ctx, c, ServiceVServer, "getLoginKeyList", "loginKeyList", nil)
 }
 
-func (c *Client) DeleteLoginKey(keyName string) error {
-	return c.DeleteLoginKeys([]string{keyName})
+func (c *Client) DeleteLoginKey(ctx context.Context, keyName string) error {
+	return c.DeleteLoginKeys(ctx, []string{keyName})
 }
 
-func (c *Client) DeleteLoginKeys(keyNames []string) error {
+func (c *Client) DeleteLoginKeys(ctx context.Context, keyNames []string) error {
 	params := url.Values{}
 	params.Set("responseFormatType", "json")
 	for i, name := range keyNames {
 		params.Set(fmt.Sprintf("keyNameList.%d", i+1), name)
 	}
 	path := "/deleteLoginKeys?" + params.Encode()
-	body, status, err := c.doRequestWithBase(VServerBaseURL, "GET", path, nil)
+	body, status, err := c.doService(ctx, ServiceVServer, "GET", path, nil)
 	if err != nil {
 		return err
 	}
 	if status != 200 {
-		return fmt.Errorf("HTTP %d - %s", status, string(body))
+		return newAPIError(ServiceVServer, "GET", path, status, body)
 	}
 	return nil
 }
 
 // --- Placement Group APIs ---
 
-func (c *Client) ListPlacementGroups() ([]PlacementGroup, error) {
-	path := "/getPlacementGroupList?responseFormatType=json"
-	body, status, err := c.doRequestWithBase(VServerBaseURL, "GET", path, nil)
-	if err != nil {
-		return nil, err
-	}
-	if status != 200 {
-		return nil, fmt.Errorf("HTTP %d - %s", status, string(body))
-	}
-	var resp struct {
-		Response getPlacementGroupListResponse `json:"getPlacementGroupListResponse"`
-	}
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("parsing response: %w", err)
-	}
-	return resp.Response.PlacementGroupList, nil
+func (c *Client) ListPlacementGroups(ctx context.Context) ([]PlacementGroup, error) {
+	return listAll[PlacementGroup](ctx, c, ServiceVServer, "getPlacementGroupList", "placementGroupList", nil)
 }
 
-func (c *Client) DeletePlacementGroup(placementGroupNo string) error {
+func (c *Client) DeletePlacementGroup(ctx context.Context, placementGroupNo string) error {
 	params := url.Values{}
 	params.Set("responseFormatType", "json")
 	params.Set("placementGroupNo", placementGroupNo)
 	path := "/deletePlacementGroup?" + params.Encode()
-	body, status, err := c.doRequestWithBase(VServerBaseURL, "GET", path, nil)
+	body, status, err := c.doService(ctx, ServiceVServer, "GET", path, nil)
 	if err != nil {
 		return err
 	}
 	if status != 200 {
-		return fmt.Errorf("HTTP %d - %s", status, string(body))
+		return newAPIError(ServiceVServer, "GET", path, status, body)
 	}
 	return nil
 }
 
 // --- Target Group (Load Balancer) APIs ---
 
-func (c *Client) ListTargetGroups() ([]TargetGroup, error) {
-	path := "/getTargetGroupList?responseFormatType=json"
-	body, status, err := c.doRequestWithBase(VLBBaseURL, "GET", path, nil)
-	if err != nil {
-		return nil, err
-	}
-	if status != 200 {
-		return nil, fmt.Errorf("HTTP %d - %s", status, string(body))
-	}
-	var resp struct {
-		Response getTargetGroupListResponse `json:"getTargetGroupListResponse"`
-	}
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("parsing response: %w", err)
-	}
-	return resp.Response.TargetGroupList, nil
+func (c *Client) ListTargetGroups(ctx context.Context) ([]TargetGroup, error) {
+	return listAll[TargetGroup](ctx, c, ServiceVLB, "getTargetGroupList", "targetGroupList", nil)
 }
 
-func (c *Client) DeleteTargetGroup(targetGroupNo string) error {
+func (c *Client) DeleteTargetGroup(ctx context.Context, targetGroupNo string) error {
 	params := url.Values{}
 	params.Set("responseFormatType", "json")
 	params.Set("targetGroupNoList.1", targetGroupNo)
 	path := "/deleteTargetGroups?" + params.Encode()
-	body, status, err := c.doRequestWithBase(VLBBaseURL, "GET", path, nil)
+	body, status, err := c.doService(ctx, ServiceVLB, "GET", path, nil)
 	if err != nil {
 		return err
 	}
 	if status != 200 {
-		return fmt.Errorf("HTTP %d - %s", status, string(body))
+		return newAPIError(ServiceVLB, "GET", path, status, body)
 	}
 	return nil
 }
 
 // --- Block Storage Snapshot APIs ---
 
-func (c *Client) ListBlockStorageSnapshotInstances() ([]BlockStorageSnapshotInstance, error) {
-	path := "/getBlockStorageSnapshotInstanceList?responseFormatType=json"
-	body, status, err := c.doRequestWithBase(VServerBaseURL, "GET", path, nil)
-	if err != nil {
-		return nil, err
-	}
-	if status != 200 {
-		return nil, fmt.Errorf("HTTP %d - %s", status, string(body))
-	}
-	var resp struct {
-		Response getBlockStorageSnapshotInstanceListResponse `json:"getBlockStorageSnapshotInstanceListResponse"`
-	}
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("parsing response: %w", err)
-	}
-	return resp.Response.BlockStorageSnapshotInstanceList, nil
+func (c *Client) ListBlockStorageSnapshotInstances(ctx context.Context) ([]BlockStorageSnapshotInstance, error) {
+	return listAll[BlockStorageSnapshotInstance](ctx, c, ServiceVServer, "getBlockStorageSnapshotInstanceList", "blockStorageSnapshotInstanceList", nil)
 }
 
-func (c *Client) DeleteBlockStorageSnapshotInstances(instanceNos []string) error {
+func (c *Client) DeleteBlockStorageSnapshotInstances(ctx context.Context, instanceNos []string) error {
 	params := url.Values{}
 	params.Set("responseFormatType", "json")
 	for i, no := range instanceNos {
 		params.Set(fmt.Sprintf("blockStorageSnapshotInstanceNoList.%d", i+1), no)
 	}
 	path := "/deleteBlockStorageSnapshotInstances?" + params.Encode()
-	body, status, err := c.doRequestWithBase(VServerBaseURL, "GET", path, nil)
+	body, status, err := c.doService(ctx, ServiceVServer, "GET", path, nil)
 	if err != nil {
 		return err
 	}
 	if status != 200 {
-		return fmt.Errorf("HTTP %d - %s", status, string(body))
+		return newAPIError(ServiceVServer, "GET", path, status, body)
 	}
 	return nil
 }
@@ -822,38 +561,23 @@ func (c *Client) DeleteBlockStorageSnapshotInstances(instanceNos []string) error
 
 // ListNasVolumeSnapshots lists snapshots for a single NAS volume.
 // The NCP VPC API requires nasVolumeInstanceNo, so snapshots cannot be listed globally.
-func (c *Client) ListNasVolumeSnapshots(nasVolumeInstanceNo string) ([]NasVolumeSnapshot, error) {
+func (c *Client) ListNasVolumeSnapshots(ctx context.Context, nasVolumeInstanceNo string) ([]NasVolumeSnapshot, error) {
 	params := url.Values{}
-	params.Set("responseFormatType", "json")
 	params.Set("nasVolumeInstanceNo", nasVolumeInstanceNo)
-	path := "/getNasVolumeSnapshotList?" + params.Encode()
-	body, status, err := c.doRequestWithBase(VNASBaseURL, "GET", path, nil)
-	if err != nil {
-		return nil, err
-	}
-	if status != 200 {
-		return nil, fmt.Errorf("HTTP %d - %s", status, string(body))
-	}
-	var resp struct {
-		Response getNasVolumeSnapshotListResponse `json:"getNasVolumeSnapshotListResponse"`
-	}
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("parsing response: %w", err)
-	}
-	return resp.Response.NasVolumeSnapshotList, nil
+	return listAll[NasVolumeSnapshot](ctx, c, ServiceVNAS, "getNasVolumeSnapshotList", "nasVolumeSnapshotList", params)
 }
 
-func (c *Client) DeleteNasVolumeSnapshot(snapshotInstanceNo string) error {
+func (c *Client) DeleteNasVolumeSnapshot(ctx context.Context, snapshotInstanceNo string) error {
 	params := url.Values{}
 	params.Set("responseFormatType", "json")
 	params.Set("nasVolumeSnapshotInstanceNo", snapshotInstanceNo)
 	path := "/deleteNasVolumeSnapshot?" + params.Encode()
-	body, status, err := c.doRequestWithBase(VNASBaseURL, "GET", path, nil)
+	body, status, err := c.doService(ctx, ServiceVNAS, "GET", path, nil)
 	if err != nil {
 		return err
 	}
 	if status != 200 {
-		return fmt.Errorf("HTTP %d - %s", status, string(body))
+		return newAPIError(ServiceVNAS, "GET", path, status, body)
 	}
 	return nil
 }
@@ -862,38 +586,47 @@ func (c *Client) DeleteNasVolumeSnapshot(snapshotInstanceNo string) error {
 
 // ListApiGatewayProducts lists API Gateway products. Endpoint: GET /products
 // (host apigateway.apigw.ntruss.com). Empty/no-tenant accounts return none.
-func (c *Client) ListApiGatewayProducts() ([]ApiGatewayProduct, error) {
-	path := "/products?offset=0&limit=200"
-	body, status, err := c.doRequestWithBase(APIGatewayBaseURL, "GET", path, nil)
-	if err != nil {
-		return nil, err
-	}
-	// tenantId-not-found (10008) on a 404 just means no API Gateway here.
-	if status == 404 && bytes.Contains(body, []byte("10008")) {
-		return nil, nil
-	}
-	if status != 200 {
-		return nil, fmt.Errorf("HTTP %d - %s", status, string(body))
-	}
-	var resp struct {
-		Products []ApiGatewayProduct `json:"products"`
-	}
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("parsing response: %w", err)
+func (c *Client) ListApiGatewayProducts(ctx context.Context) ([]ApiGatewayProduct, error) {
+	var all []ApiGatewayProduct
+	for offset := 0; ; {
+		path := fmt.Sprintf("/products?offset=%d&limit=%d", offset, c.pageSize)
+		body, status, err := c.doService(ctx, ServiceAPIGateway, "GET", path, nil)
+		if err != nil {
+			return nil, err
+		}
+		if status != 200 {
+			apiErr := newAPIError(ServiceAPIGateway, "GET", path, status, body)
+			// tenantId-not-found (10008) on a 404 just means no API Gateway here.
+			if status == 404 && apiErr.ReturnCode == "10008" {
+				return nil, nil
+			}
+			return nil, apiErr
+		}
+		var resp struct {
+			Products []ApiGatewayProduct `json:"products"`
+			Total    int                 `json:"total"`
+		}
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return nil, fmt.Errorf("parsing response: %w", err)
+		}
+		all = append(all, resp.Products...)
+		offset += len(resp.Products)
+		if len(resp.Products) < c.pageSize || (resp.Total > 0 && offset >= resp.Total) {
+			return all, nil
+		}
 	}
-	return resp.Products, nil
 }
 
 // DeleteApiGatewayProduct deletes an API Gateway product. Endpoint:
 // DELETE /products/{productId}.
-func (c *Client) DeleteApiGatewayProduct(productID string) error {
+func (c *Client) DeleteApiGatewayProduct(ctx context.Context, productID string) error {
 	path := "/products/" + productID
-	body, status, err := c.doRequestWithBase(APIGatewayBaseURL, "DELETE", path, nil)
+	body, status, err := c.doService(ctx, ServiceAPIGateway, "DELETE", path, nil)
 	if err != nil {
 		return err
 	}
 	if status != 200 && status != 204 {
-		return fmt.Errorf("HTTP %d - %s", status, string(body))
+		return newAPIError(ServiceAPIGateway, "DELETE", path, status, body)
 	}
 	return nil
 }
@@ -0,0 +1,140 @@
+package ncp
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"ncp-nuke/pkg/progress"
+)
+
+// --- Classic List APIs ---
+
+func (c *Client) ListClassicServers(ctx context.Context) ([]ServerInstance, error) {
+	return listAll[ServerInstance](ctx, c, ServiceServer, "getServerInstanceList", "serverInstanceList", nil)
+}
+
+func (c *Client) ListClassicBlockStorages(ctx context.Context) ([]BlockStorageInstance, error) {
+	return listAll[BlockStorageInstance](ctx, c, ServiceServer, "getBlockStorageInstanceList", "blockStorageInstanceList", nil)
+}
+
+func (c *Client) ListClassicPublicIps(ctx context.Context) ([]ClassicPublicIpInstance, error) {
+	return listAll[ClassicPublicIpInstance](ctx, c, ServiceServer, "getPublicIpInstanceList", "publicIpInstanceList", nil)
+}
+
+func (c *Client) ListClassicNasVolumes(ctx context.Context) ([]NasVolumeInstance, error) {
+	return listAll[NasVolumeInstance](ctx, c, ServiceServer, "getNasVolumeInstanceList", "nasVolumeInstanceList", nil)
+}
+
+func (c *Client) ListClassicLoadBalancers(ctx context.Context) ([]LoadBalancerInstance, error) {
+	return listAll[LoadBalancerInstance](ctx, c, ServiceLoadBalancer, "getLoadBalancerInstanceList", "loadBalancerInstanceList", nil)
+}
+
+// prepareClassicServers stops the running servers and deletes their attached
+// additional block storages (a server with one cannot be returned). The
+// storages deleted here are confirmed gone by the Classic Block Storage type.
+func (c *Client) prepareClassicServers(ctx context.Context, summary *ResourceSummary, servers []ServerInstance, l progress.Logger) {
+	serverNos := make(map[string]bool)
+	var runningNos []string
+	for _, s := range servers {
+		serverNos[s.ServerInstanceNo] = true
+		if s.ServerInstanceStatus.Code == "RUN" {
+			runningNos = append(runningNos, s.ServerInstanceNo)
+		}
+	}
+	if len(runningNos) > 0 {
+		l.Info(1, "Classic 서버 %d대 정지 중...", len(runningNos))
+		if err := c.StopClassicServers(ctx, runningNos); err != nil {
+			l.Fail(2, err, "[실패] 서버 정지: %v", err)
+		} else {
+			l.Info(2, "서버 정지 요청 완료, 정지 완료 대기 중...")
+			waitServersStopped(ctx, c.ListClassicServers, runningNos, l)
+		}
+	}
+
+	if ctx.Err() != nil {
+		return
+	}
+	// 추가 Block Storage (BASIC은 서버와 함께 반납)
+	var storageNos []string
+	for _, bs := range summary.ClassicBlockStorages {
+		if bs.BlockStorageType.Code != "BASIC" && serverNos[bs.ServerInstanceNo] {
+			storageNos = append(storageNos, bs.BlockStorageInstanceNo)
+		}
+	}
+	if len(storageNos) > 0 {
+		l.Info(1, "Classic 서버 추가 블록 스토리지 %d개 삭제 중...", len(storageNos))
+		if err := c.DeleteClassicBlockStorages(ctx, storageNos); err != nil {
+			l.Fail(2, err, "[실패] %v", err)
+		} else {
+			l.OK(2, "[성공]")
+		}
+	}
+}
+
+// releaseClassicPublicIp disassociates a public IP from its server and
+// deletes it.
+func (c *Client) releaseClassicPublicIp(ctx context.Context, ip ClassicPublicIpInstance, l progress.Logger) error {
+	if ip.ServerInstance != nil && ip.ServerInstance.ServerInstanceNo != "" {
+		if err := c.DisassociateClassicPublicIp(ctx, ip.PublicIpInstanceNo); err != nil {
+			l.Fail(2, err, "[실패] 연결 해제: %v", err)
+		} else {
+			sleepCtx(ctx, 3*time.Second)
+		}
+	}
+	return c.DeleteClassicPublicIps(ctx, []string{ip.PublicIpInstanceNo})
+}
+
+// --- Classic Delete APIs ---
+
+// classicAction calls a Classic v2 action with the given parameters.
+func (c *Client) classicAction(ctx context.Context, svc Service, action string, params url.Values) error {
+	params.Set("responseFormatType", "json")
+	path := "/" + action + "?" + params.Encode()
+	body, status, err := c.doService(ctx, svc, "GET", path, nil)
+	if err != nil {
+		return err
+	}
+	if status != 200 {
+		return newAPIError(svc, "GET", path, status, body)
+	}
+	return nil
+}
+
+// indexedParams builds name.1, name.2, ... list parameters.
+func indexedParams(name string, values []string) url.Values {
+	params := url.Values{}
+	for i, v := range values {
+		params.Set(fmt.Sprintf("%s.%d", name, i+1), v)
+	}
+	return params
+}
+
+func (c *Client) StopClassicServers(ctx context.Context, instanceNos []string) error {
+	return c.classicAction(ctx, ServiceServer, "stopServerInstances", indexedParams("serverInstanceNoList", instanceNos))
+}
+
+func (c *Client) TerminateClassicServers(ctx context.Context, instanceNos []string) error {
+	return c.classicAction(ctx, ServiceServer, "terminateServerInstances", indexedParams("serverInstanceNoList", instanceNos))
+}
+
+func (c *Client) DeleteClassicBlockStorages(ctx context.Context, instanceNos []string) error {
+	return c.classicAction(ctx, ServiceServer, "deleteBlockStorageInstances", indexedParams("blockStorageInstanceNoList", instanceNos))
+}
+
+func (c *Client) DisassociateClassicPublicIp(ctx context.Context, publicIpInstanceNo string) error {
+	return c.classicAction(ctx, ServiceServer, "disassociatePublicIpFromServerInstance", url.Values{"publicIpInstanceNo": {publicIpInstanceNo}})
+}
+
+func (c *Client) DeleteClassicPublicIps(ctx context.Context, instanceNos []string) error {
+	return c.classicAction(ctx, ServiceServer, "deletePublicIpInstances", indexedParams("publicIpInstanceNoList", instanceNos))
+}
+
+func (c *Client) DeleteClassicNasVolume(ctx context.Context, nasVolumeInstanceNo string) error {
+	return c.classicAction(ctx, ServiceServer, "deleteNasVolumeInstance", url.Values{"nasVolumeInstanceNo": {nasVolumeInstanceNo}})
+}
+
+func (c *Client) DeleteClassicLoadBalancer(ctx context.Context, loadBalancerInstanceNo string) error {
+	return c.classicAction(ctx, ServiceLoadBalancer, "deleteLoadBalancerInstances", indexedParams("loadBalancerInstanceNoList", []string{loadBalancerInstanceNo}))
+}
@@ -0,0 +1,275 @@
+package ncp_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"ncp-nuke/pkg/ncp"
+	"ncp-nuke/pkg/ncp/ncptest"
+	"ncp-nuke/pkg/progress"
+)
+
+// newTestClient starts a fake NCP server with one account and returns a
+// client of that account, without rate limiting.
+func newTestClient(t *testing.T, opts ...ncp.Option) (*ncptest.Server, *ncptest.Account, *ncp.Client) {
+	t.Helper()
+	srv := ncptest.NewServer()
+	t.Cleanup(srv.Close)
+	acct := srv.AddAccount("AK-"+t.Name(), "SK")
+	opts = append(append(srv.ClientOptions(), ncp.WithRateLimit(0, 0)), opts...)
+	return srv, acct, ncp.NewClient("AK-"+t.Name(), "SK", opts...)
+}
+
+// seedVpc seeds a VPC with a subnet, a server with a data disk and a public
+// IP, a NAT gateway routed from a route table and an ACG.
+func seedVpc(acct *ncptest.Account) {
+	acct.Seed(ncptest.Vpcs, ncp.Vpc{VpcNo: "v1", VpcName: "lab-vpc"})
+	acct.Seed(ncptest.Subnets, ncp.Subnet{SubnetNo: "s1", SubnetName: "lab-subnet", VpcNo: "v1"})
+	acct.Seed(ncptest.Servers, ncp.ServerInstance{
+		ServerInstanceNo: "100", ServerName: "web", VpcNo: "v1", SubnetNo: "s1",
+		ServerInstanceStatus: ncp.CommonCode{Code: "RUN"},
+	})
+	acct.Seed(ncptest.BlockStorages,
+		ncp.BlockStorageInstance{BlockStorageInstanceNo: "200", BlockStorageName: "root", ServerInstanceNo: "100", BlockStorageDiskDetailType: ncp.CommonCode{Code: "BASIC"}},
+		ncp.BlockStorageInstance{BlockStorageInstanceNo: "201", BlockStorageName: "data", ServerInstanceNo: "100"},
+	)
+	acct.Seed(ncptest.PublicIps, ncp.PublicIpInstance{PublicIpInstanceNo: "300", PublicIp: "203.0.113.1", ServerInstanceNo: "100"})
+	acct.Seed(ncptest.NatGateways, ncp.NatGatewayInstance{NatGatewayInstanceNo: "400", NatGatewayName: "nat", VpcNo: "v1", SubnetNo: "s1"})
+	acct.Seed(ncptest.RouteTables, ncp.RouteTable{
+		RouteTableNo: "500", RouteTableName: "private", VpcNo: "v1",
+		RouteList: []ncp.Route{{DestinationCidrBlock: "0.0.0.0/0", TargetNo: "400", TargetTypeCode: ncp.CommonCode{Code: "NATGW"}}},
+	})
+	acct.Seed(ncptest.AccessControlGroups, ncp.AccessControlGroup{AccessControlGroupNo: "600", AccessControlGroupName: "web-acg", VpcNo: "v1"})
+}
+
+func TestListAllResources(t *testing.T) {
+	_, acct, c := newTestClient(t)
+	seedVpc(acct)
+	acct.Seed(ncptest.LoginKeys, ncp.LoginKey{KeyName: "key"})
+	acct.Seed(ncptest.InstanceTags, ncp.InstanceTag{InstanceNo: "100", TagKey: "env", TagValue: "dev"})
+
+	s, errs := c.ListAllResources(context.Background())
+	if len(errs) > 0 {
+		t.Fatalf("ListAllResources errors: %v", errs)
+	}
+	want := map[string]int{
+		"servers": 1, "block_storages": 2, "public_ips": 1, "vpcs": 1, "subnets": 1,
+		"nat_gateways": 1, "route_tables": 1, "access_control_groups": 1, "login_keys": 1,
+	}
+	for _, rt := range ncp.ResourceTypes() {
+		if got := rt.Count(s); got != want[rt.Key()] {
+			t.Errorf("%s: got %d, want %d", rt.Key(), got, want[rt.Key()])
+		}
+	}
+	if got := s.Tags["100"]["env"]; got != "dev" {
+		t.Errorf("server tag env = %q, want dev", got)
+	}
+
+	// Resources attached to a server take its VPC and tags.
+	ips, _ := ncp.LookupResourceType("public_ips")
+	it := ips.Items(s)[0]
+	if it.VpcName != "lab-vpc" || it.Tags["env"] != "dev" {
+		t.Errorf("public IP item = %+v, want the server's VPC and tags", it)
+	}
+}
+
+func TestListAllResourcesPaging(t *testing.T) {
+	_, acct, c := newTestClient(t, ncp.WithPageSize(2))
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		acct.Seed(ncptest.LoginKeys, ncp.LoginKey{KeyName: name})
+	}
+	keys, err := c.ListLoginKeys(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 5 {
+		t.Errorf("got %d login keys over pages of 2, want 5", len(keys))
+	}
+}
+
+func TestRetry(t *testing.T) {
+	srv, acct, c := newTestClient(t, ncp.WithRetryPolicy(ncp.RetryPolicy{MaxAttempts: 3}))
+	acct.Seed(ncptest.LoginKeys, ncp.LoginKey{KeyName: "key"})
+
+	srv.FailNext(2, 503)
+	keys, err := c.ListLoginKeys(context.Background())
+	if err != nil || len(keys) != 1 {
+		t.Fatalf("after two 503s: keys = %v, err = %v", keys, err)
+	}
+
+	srv.FailNext(3, 429)
+	if _, err := c.ListLoginKeys(context.Background()); err == nil {
+		t.Fatal("three 429s with 3 attempts: want an error")
+	}
+}
+
+// countingTransport answers every request with respond and counts them.
+type countingTransport struct {
+	calls   int
+	respond func(*http.Request) (*http.Response, error)
+}
+
+func (t *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	t.calls++
+	return t.respond(r)
+}
+
+// errReader fails every read with err.
+type errReader struct{ err error }
+
+func (r errReader) Read([]byte) (int, error) { return 0, r.err }
+
+func TestRetryTransportErrors(t *testing.T) {
+	policy := ncp.WithRetryPolicy(ncp.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond})
+	body := func(err error) func(*http.Request) (*http.Response, error) {
+		return func(r *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(errReader{err}), Request: r}, nil
+		}
+	}
+	for _, tt := range []struct {
+		name    string
+		respond func(*http.Request) (*http.Response, error)
+		want    int
+	}{
+		{"connection error", func(*http.Request) (*http.Response, error) { return nil, syscall.ECONNRESET }, 3},
+		{"truncated response", body(io.ErrUnexpectedEOF), 3},
+		{"transport misconfigured", func(*http.Request) (*http.Response, error) { return nil, errors.New("no proxy") }, 1},
+		{"unreadable response", body(errors.New("broken body")), 1},
+	} {
+		tr := &countingTransport{respond: tt.respond}
+		_, _, c := newTestClient(t, policy, ncp.WithHTTPClient(&http.Client{Transport: tr}))
+		if _, err := c.ListLoginKeys(context.Background()); err == nil {
+			t.Errorf("%s: want an error", tt.name)
+		}
+		if tr.calls != tt.want {
+			t.Errorf("%s: %d attempts, want %d", tt.name, tr.calls, tt.want)
+		}
+	}
+
+	// A request that cannot be built fails the same way every time.
+	slow := ncp.WithRetryPolicy(ncp.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Hour, MaxDelay: time.Hour})
+	_, _, c := newTestClient(t, slow, ncp.WithEndpoint(ncp.ServiceVServer, "http://bad host"))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := c.ListLoginKeys(ctx); err == nil || errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("bad endpoint: err = %v, want the request error without retries", err)
+	}
+}
+
+func TestCleanupAllResources(t *testing.T) {
+	srv, acct, c := newTestClient(t)
+	seedVpc(acct)
+	ctx := context.Background()
+
+	s, errs := c.ListAllResources(ctx)
+	if len(errs) > 0 {
+		t.Fatalf("ListAllResources errors: %v", errs)
+	}
+	s.DropImplicit()
+	success, fail := c.CleanupAllResources(ctx, s, progress.SinkFunc(func(progress.Event) {}))
+	if fail != 0 {
+		t.Errorf("fail = %d, want 0", fail)
+	}
+	if success != s.TotalCount() {
+		t.Errorf("success = %d, want %d", success, s.TotalCount())
+	}
+	if n := acct.Total(); n != 0 {
+		t.Errorf("%d resources left", n)
+	}
+
+	// Every type goes only after the types that must go first.
+	at := func(action string) int {
+		for i, r := range srv.Requests() {
+			if strings.HasSuffix(r, "/"+action) {
+				return i
+			}
+		}
+		t.Fatalf("no %s request", action)
+		return -1
+	}
+	for _, order := range [][2]string{
+		{"terminateServerInstances", "deleteBlockStorageInstances"},
+		{"terminateServerInstances", "deletePublicIpInstance"},
+		{"terminateServerInstances", "deleteSubnet"},
+		{"removeRoute", "deleteNatGatewayInstance"},
+		{"deleteNatGatewayInstance", "deleteSubnet"},
+		{"deleteSubnet", "deleteVpc"},
+		{"deleteAccessControlGroup", "deleteVpc"},
+	} {
+		if at(order[0]) > at(order[1]) {
+			t.Errorf("%s ran after %s", order[0], order[1])
+		}
+	}
+}
+
+func TestCleanupAllResourcesCancelled(t *testing.T) {
+	_, acct, c := newTestClient(t)
+	seedVpc(acct)
+
+	s, _ := c.ListAllResources(context.Background())
+	s.DropImplicit()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	success, _ := c.CleanupAllResources(ctx, s, progress.SinkFunc(func(progress.Event) {}))
+	if success != 0 {
+		t.Errorf("success = %d after cancel, want 0", success)
+	}
+	if n := acct.Count(ncptest.Servers); n != 1 {
+		t.Errorf("%d servers left after cancel, want 1", n)
+	}
+}
+
+func TestCleanupClassic(t *testing.T) {
+	_, acct, c := newTestClient(t, ncp.WithPlatform(ncp.PlatformAll))
+	acct.EnableClassic()
+	acct.Seed(ncptest.ClassicServers, ncp.ServerInstance{ServerInstanceNo: "900", ServerName: "legacy", ServerInstanceStatus: ncp.CommonCode{Code: "RUN"}})
+	acct.Seed(ncptest.ClassicPublicIps, ncp.ClassicPublicIpInstance{PublicIpInstanceNo: "901", PublicIp: "198.51.100.1"})
+	ctx := context.Background()
+
+	s, errs := c.ListAllResources(ctx)
+	if len(errs) > 0 {
+		t.Fatalf("ListAllResources errors: %v", errs)
+	}
+	if len(s.ClassicServers) != 1 || len(s.ClassicPublicIps) != 1 {
+		t.Fatalf("classic servers %d, public IPs %d, want 1 and 1", len(s.ClassicServers), len(s.ClassicPublicIps))
+	}
+	s.DropImplicit()
+	if _, fail := c.CleanupAllResources(ctx, s, progress.SinkFunc(func(progress.Event) {})); fail != 0 {
+		t.Errorf("fail = %d, want 0", fail)
+	}
+	if n := acct.Total(); n != 0 {
+		t.Errorf("%d resources left", n)
+	}
+}
+
+func TestSupportsClassic(t *testing.T) {
+	srv, acct, c := newTestClient(t, ncp.WithRetryPolicy(ncp.RetryPolicy{MaxAttempts: 1}))
+	ctx := context.Background()
+
+	if ok, err := c.SupportsClassic(ctx); ok || err != nil {
+		t.Errorf("VPC-only account: %v, %v, want false without error", ok, err)
+	}
+
+	// A failed probe is an error, not an account without Classic.
+	for _, status := range []int{429, 503, 401} {
+		srv.FailNext(1, status)
+		if _, err := c.SupportsClassic(ctx); err == nil {
+			t.Errorf("HTTP %d: want an error", status)
+		}
+	}
+	srv.FailNext(1, 503)
+	if _, errs := c.ListAllResources(ctx); len(errs) == 0 {
+		t.Error("ListAllResources hides the failed Classic probe")
+	}
+
+	acct.EnableClassic()
+	if ok, err := c.SupportsClassic(ctx); !ok || err != nil {
+		t.Errorf("Classic account: %v, %v, want true", ok, err)
+	}
+}
@@ -1,13 +1,18 @@
 package ncp
 
 import (
+	"bytes"
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/base64"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -26,43 +31,287 @@ const (
 	VMariaDBBaseURL     = "https://ncloud.apigw.ntruss.com/vmariadb/v2"
 	VMySQLBaseURL       = "https://ncloud.apigw.ntruss.com/vmysql/v2"
 	VRedisBaseURL       = "https://ncloud.apigw.ntruss.com/vredis/v2"
+	BillingBaseURL      = "https://billingapi.apigw.ntruss.com/billing/v1/cost"
+
+	// Classic platform APIs.
+	ServerBaseURL       = "https://ncloud.apigw.ntruss.com/server/v2"
+	LoadBalancerBaseURL = "https://ncloud.apigw.ntruss.com/loadbalancer/v2"
+)
+
+// Service identifies an NCP API product. Each service has its own base URL,
+// which can be overridden per client (see WithEndpoint).
+type Service string
+
+const (
+	ServiceSubAccount    Service = "subaccount"
+	ServiceVServer       Service = "vserver"
+	ServiceVNAS          Service = "vnas"
+	ServiceVLB           Service = "vloadbalancer"
+	ServiceCloudDB       Service = "clouddb"
+	ServiceVPC           Service = "vpc"
+	ServiceVNKS          Service = "vnks"
+	ServiceVAutoScaling  Service = "vautoscaling"
+	ServiceAPIGateway    Service = "apigateway"
+	ServiceVMongoDB      Service = "vmongodb"
+	ServiceVPostgreSQL   Service = "vpostgresql"
+	ServiceVMariaDB      Service = "vmariadb"
+	ServiceVMySQL        Service = "vmysql"
+	ServiceVRedis        Service = "vredis"
+	ServiceObjectStorage Service = "objectstorage"
+	ServiceBilling       Service = "billing"
+	ServiceServer        Service = "server"       // Classic
+	ServiceLoadBalancer  Service = "loadbalancer" // Classic
 )
 
+// DefaultEndpoints is the base URL of every service on the public NCP site
+// (see Site.Endpoints for the Financial and Government clouds).
+var DefaultEndpoints = map[Service]string{
+	ServiceSubAccount:    SubAccountBaseURL,
+	ServiceVServer:       VServerBaseURL,
+	ServiceVNAS:          VNASBaseURL,
+	ServiceVLB:           VLBBaseURL,
+	ServiceCloudDB:       VCloudDBBaseURL,
+	ServiceVPC:           VVPCBaseURL,
+	ServiceVNKS:          VNKSBaseURL,
+	ServiceVAutoScaling:  VAutoScalingBaseURL,
+	ServiceAPIGateway:    APIGatewayBaseURL,
+	ServiceVMongoDB:      VMongoDBBaseURL,
+	ServiceVPostgreSQL:   VPostgreSQLBaseURL,
+	ServiceVMariaDB:      VMariaDBBaseURL,
+	ServiceVMySQL:        VMySQLBaseURL,
+	ServiceVRedis:        VRedisBaseURL,
+	ServiceObjectStorage: defaultObjectStorageEndpoint,
+	ServiceBilling:       BillingBaseURL,
+	ServiceServer:        ServerBaseURL,
+	ServiceLoadBalancer:  LoadBalancerBaseURL,
+}
+
+// Services returns every known service in a stable order.
+func Services() []Service {
+	return []Service{
+		ServiceSubAccount, ServiceVServer, ServiceVNAS, ServiceVLB, ServiceCloudDB,
+		ServiceVPC, ServiceVNKS, ServiceVAutoScaling, ServiceAPIGateway,
+		ServiceVMongoDB, ServiceVPostgreSQL, ServiceVMariaDB, ServiceVMySQL,
+		ServiceVRedis, ServiceObjectStorage, ServiceBilling, ServiceServer, ServiceLoadBalancer,
+	}
+}
+
 // Client is the NCP API client with HMAC-SHA256 authentication.
 type Client struct {
 	accessKey  string
 	secretKey  string
 	httpClient *http.Client
+	endpoints  map[Service]string
+	retry      RetryPolicy
+	limiter    *tokenBucket // shared by every client of the access key (see sharedBucket)
+	rateLimit  float64      // requests/second of the limiter, <= 0 = none
+	rateBurst  int
+	pageSize   int
+	region     string // regionCode sent to regional APIs; "" = API default (KR)
+	site       Site
+	platform   Platform
+	workers    int             // concurrent deletions in CleanupAllResources
+	journal    func(Operation) // receives every deletion CleanupAllResources attempts
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithEndpoint overrides the base URL of a single service.
+func WithEndpoint(svc Service, baseURL string) Option {
+	return func(c *Client) {
+		if baseURL != "" {
+			c.endpoints[svc] = strings.TrimRight(baseURL, "/")
+		}
+	}
+}
+
+// WithEndpoints overrides the base URLs of several services at once.
+func WithEndpoints(endpoints map[Service]string) Option {
+	return func(c *Client) {
+		for svc, u := range endpoints {
+			WithEndpoint(svc, u)(c)
+		}
+	}
+}
+
+// WithHTTPClient replaces the underlying HTTP client (e.g. for a test server).
+func WithHTTPClient(h *http.Client) Option {
+	return func(c *Client) {
+		if h != nil {
+			c.httpClient = h
+		}
+	}
+}
+
+// WithRegion scopes the client to a region (e.g. "KR", "SGN", "JPN").
+func WithRegion(code string) Option {
+	return func(c *Client) {
+		c.region = strings.ToUpper(strings.TrimSpace(code))
+	}
 }
 
-// NewClient creates a new NCP API client.
-func NewClient(accessKey, secretKey string) *Client {
-	return &Client{
+// NewClient creates a new NCP API client. Endpoints default to the public NCP
+// site, then NCP_ENDPOINT_<SERVICE> environment variables (e.g.
+// NCP_ENDPOINT_VSERVER), then the given options.
+func NewClient(accessKey, secretKey string, opts ...Option) *Client {
+	c := &Client{
 		accessKey: accessKey,
 		secretKey: secretKey,
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		endpoints: make(map[Service]string, len(DefaultEndpoints)),
+		retry:     DefaultRetryPolicy,
+		rateLimit: DefaultRateLimit,
+		rateBurst: DefaultRateBurst,
+		pageSize:  DefaultPageSize,
+		site:      SitePublic,
+		platform:  PlatformAuto,
+		workers:   DefaultWorkers,
+	}
+	for svc, u := range DefaultEndpoints {
+		c.endpoints[svc] = u
+	}
+	WithEndpoints(endpointsFromEnv())(c)
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.limiter = sharedBucket(c.accessKey, c.site, c.rateLimit, c.rateBurst)
+	return c
+}
+
+// endpointsFromEnv reads NCP_ENDPOINT_<SERVICE> overrides. The legacy
+// NCP_OBJECT_STORAGE_ENDPOINT variable is still honored for Object Storage.
+func endpointsFromEnv() map[Service]string {
+	m := map[Service]string{}
+	if v := os.Getenv("NCP_OBJECT_STORAGE_ENDPOINT"); v != "" {
+		m[ServiceObjectStorage] = v
+	}
+	for _, svc := range Services() {
+		if v := os.Getenv("NCP_ENDPOINT_" + strings.ToUpper(string(svc))); v != "" {
+			m[svc] = v
+		}
+	}
+	return m
+}
+
+// Endpoint returns the base URL the client uses for a service.
+func (c *Client) Endpoint(svc Service) string {
+	return c.endpoints[svc]
+}
+
+// Region returns the region code the client is scoped to ("" = API default).
+func (c *Client) Region() string {
+	return c.region
+}
+
+// ForRegion returns a copy of the client scoped to another region. The copy
+// shares the HTTP client and rate limiter (same access key). An empty code
+// returns c itself.
+func (c *Client) ForRegion(code string) *Client {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" || code == c.region {
+		return c
+	}
+	rc := *c
+	rc.region = code
+	rc.endpoints = make(map[Service]string, len(c.endpoints))
+	for svc, u := range c.endpoints {
+		rc.endpoints[svc] = u
 	}
+	return &rc
+}
+
+// regionalServices take the target region as a regionCode query parameter.
+// NKS takes it as a header; Sub Account and API Gateway are not region-scoped.
+var regionalServices = map[Service]bool{
+	ServiceVServer: true, ServiceVNAS: true, ServiceVLB: true, ServiceCloudDB: true,
+	ServiceVPC: true, ServiceVAutoScaling: true, ServiceVMongoDB: true,
+	ServiceVPostgreSQL: true, ServiceVMariaDB: true, ServiceVMySQL: true, ServiceVRedis: true,
+	ServiceServer: true, ServiceLoadBalancer: true,
+}
+
+// regionalPath appends regionCode to path for regional services.
+func (c *Client) regionalPath(svc Service, path string) string {
+	if c.region == "" || !regionalServices[svc] || strings.Contains(path, "regionCode=") {
+		return path
+	}
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+	return path + sep + "regionCode=" + url.QueryEscape(c.region)
 }
 
 // makeSignature generates the HMAC-SHA256 signature for NCP API authentication.
 // Format: {method} {url}\n{timestamp}\n{accessKey}
 func (c *Client) makeSignature(method, url, timestamp string) string {
-	message := fmt.Sprintf("%s %s\n%s\n%s", method, url, timestamp, c.accessKey)
-	mac := hmac.New(sha256.New, []byte(c.secretKey))
+	return MakeSignature(c.secretKey, method, url, timestamp, c.accessKey)
+}
+
+// MakeSignature computes the x-ncp-apigw-signature-v2 header value for a
+// request. It is exported so fake servers can verify signatures.
+func MakeSignature(secretKey, method, url, timestamp, accessKey string) string {
+	message := fmt.Sprintf("%s %s\n%s\n%s", method, url, timestamp, accessKey)
+	mac := hmac.New(sha256.New, []byte(secretKey))
 	mac.Write([]byte(message))
 	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
 }
 
 // doRequest executes an HTTP request with NCP authentication headers.
-// Uses SubAccountBaseURL by default.
-func (c *Client) doRequest(method, path string, body io.Reader) ([]byte, int, error) {
-	return c.doRequestWithBase(SubAccountBaseURL, method, path, body)
+// Uses the Sub Account endpoint by default.
+func (c *Client) doRequest(ctx context.Context, method, path string, body io.Reader) ([]byte, int, error) {
+	return c.doService(ctx, ServiceSubAccount, method, path, body)
+}
+
+// doService executes an HTTP request against the configured endpoint of svc,
+// scoped to the client's region.
+func (c *Client) doService(ctx context.Context, svc Service, method, path string, body io.Reader) ([]byte, int, error) {
+	var header http.Header
+	if svc == ServiceVNKS && c.region != "" {
+		header = http.Header{"x-ncp-region_code": {c.region}}
+	}
+	return c.doRequestWithBase(ctx, c.Endpoint(svc), method, c.regionalPath(svc, path), header, body)
 }
 
 // doRequestWithBase executes an HTTP request against a specific base URL.
-func (c *Client) doRequestWithBase(baseURL, method, path string, body io.Reader) ([]byte, int, error) {
+// Every attempt waits for the client's rate limiter; 429, 5xx and network
+// errors are retried according to the client's RetryPolicy. header may be nil.
+func (c *Client) doRequestWithBase(ctx context.Context, baseURL, method, path string, header http.Header, body io.Reader) ([]byte, int, error) {
+	// Buffer the body so it can be replayed on retries.
+	var payload []byte
+	if body != nil {
+		b, err := io.ReadAll(body)
+		if err != nil {
+			return nil, 0, fmt.Errorf("reading request body: %w", err)
+		}
+		payload = b
+	}
+
+	attempts := max(c.retry.MaxAttempts, 1)
+	for attempt := 1; ; attempt++ {
+		if err := c.limiter.wait(ctx); err != nil {
+			return nil, 0, err
+		}
+		respBody, resp, err := c.attempt(ctx, baseURL, method, path, header, payload)
+		if attempt >= attempts || ctx.Err() != nil {
+			return respBody, statusOf(resp), err
+		}
+		if err == nil && !shouldRetry(resp.StatusCode) {
+			return respBody, resp.StatusCode, nil
+		}
+		if err != nil && !retryableError(err) {
+			return respBody, statusOf(resp), err
+		}
+		if err := sleepCtx(ctx, c.retry.backoff(attempt, resp)); err != nil {
+			return nil, 0, err
+		}
+	}
+}
+
+// attempt performs a single signed request.
+func (c *Client) attempt(ctx context.Context, baseURL, method, path string, header http.Header, payload []byte) ([]byte, *http.Response, error) {
 	timestamp := strconv.FormatInt(time.Now().UnixMilli(), 10)
 
 	// For signature, we need the full path including the base path portion
@@ -71,9 +320,13 @@ func (c *Client) doRequestWithBase(baseURL, method, path string, body io.Reader)
 	signPath := extractPath(fullURL)
 	signature := c.makeSignature(method, signPath, timestamp)
 
-	req, err := http.NewRequest(method, fullURL, body)
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, fullURL, body)
 	if err != nil {
-		return nil, 0, fmt.Errorf("creating request: %w", err)
+		return nil, nil, fmt.Errorf("creating request: %w", err)
 	}
 
 	req.Header.Set("x-ncp-apigw-timestamp", timestamp)
@@ -81,19 +334,29 @@ func (c *Client) doRequestWithBase(baseURL, method, path string, body io.Reader)
 	req.Header.Set("x-ncp-apigw-signature-v2", signature)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
+	for k, v := range header {
+		req.Header[k] = v
+	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, 0, fmt.Errorf("executing request: %w", err)
+		return nil, nil, fmt.Errorf("executing request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, resp.StatusCode, fmt.Errorf("reading response: %w", err)
+		return nil, resp, fmt.Errorf("reading response: %w", err)
 	}
 
-	return respBody, resp.StatusCode, nil
+	return respBody, resp, nil
+}
+
+func statusOf(resp *http.Response) int {
+	if resp == nil {
+		return 0
+	}
+	return resp.StatusCode
 }
 
 // extractPath extracts the path (including query string) from a full URL.
@@ -0,0 +1,314 @@
+package ncp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"ncp-nuke/pkg/progress"
+)
+
+// DefaultWorkers is how many resources CleanupAllResources deletes at once.
+const DefaultWorkers = 4
+
+// WithWorkers sets how many resources CleanupAllResources deletes at once.
+func WithWorkers(n int) Option {
+	return func(c *Client) {
+		if n > 0 {
+			c.workers = n
+		}
+	}
+}
+
+// Workers returns the deletion concurrency of the client.
+func (c *Client) Workers() int {
+	return c.workers
+}
+
+// OpStatus is the state of a deletion reported to a journal.
+type OpStatus string
+
+const (
+	OpRequested OpStatus = "requested" // about to call the delete API
+	OpSucceeded OpStatus = "ok"
+	OpFailed    OpStatus = "failed"
+)
+
+// Operation is one deletion attempted by CleanupAllResources.
+type Operation struct {
+	Region string
+	Type   string // config key of the resource type
+	ID     string
+	Name   string
+	Status OpStatus
+	Err    error
+}
+
+// WithJournal makes CleanupAllResources report every deletion it attempts to
+// fn: once before the delete request and once with its result. fn is called
+// from concurrent workers.
+func WithJournal(fn func(Operation)) Option {
+	return func(c *Client) {
+		c.journal = fn
+	}
+}
+
+// record reports op to the client's journal, if any.
+func (c *Client) record(op Operation) {
+	if c.journal != nil {
+		c.journal(op)
+	}
+}
+
+// cleanupRun is the state shared by the resource types of one
+// CleanupAllResources call.
+type cleanupRun struct {
+	c       *Client
+	summary *ResourceSummary
+	sem     chan struct{} // one slot per worker
+
+	mu sync.Mutex
+	l  progress.Logger // the region's logger, in the delete phase
+}
+
+// acquire takes a worker slot, or returns false without one once ctx is
+// done.
+func (r *cleanupRun) acquire(ctx context.Context) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	select {
+	case r.sem <- struct{}{}:
+		if ctx.Err() != nil {
+			<-r.sem
+			return false
+		}
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// emit passes events on as one block, so concurrent deletions do not
+// interleave.
+func (r *cleanupRun) emit(events ...progress.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, e := range events {
+		r.l.Emit(e)
+	}
+}
+
+// typeLogger returns a Logger for the events of one resource type, emitted
+// right away.
+func (r *cleanupRun) typeLogger(key string) progress.Logger {
+	sink := progress.SinkFunc(func(e progress.Event) { r.emit(e) })
+	return progress.NewLogger(sink).Type(key)
+}
+
+// typeResult is the outcome of deleting one resource type.
+type typeResult struct {
+	success, fail int
+	// clean means every resource of the type is confirmed gone, which is
+	// what the types depending on it wait for.
+	clean bool
+}
+
+// CleanupAllResources deletes all resources for a root account.
+// The resource types form a dependency graph (see ResourceType.Dependents):
+// a type starts as soon as every type that must go first is confirmed gone,
+// so independent types (e.g. Cloud DBs, buckets, NKS) run side by side, and
+// the resources of a type are deleted concurrently by up to Workers workers.
+// A type whose dependents could not be removed is skipped and counted as
+// failed. Progress is reported to sink.
+func (c *Client) CleanupAllResources(ctx context.Context, summary *ResourceSummary, sink progress.EventSink) (int, int) {
+	l := progress.NewLogger(sink).Phase(progress.PhaseDelete).Region(summary.Region)
+	r := &cleanupRun{c: c, summary: summary, sem: make(chan struct{}, c.workers), l: l}
+
+	done := make(map[string]chan struct{}, len(deletionOrder))
+	results := make(map[string]*typeResult, len(deletionOrder))
+	for _, rt := range deletionOrder {
+		done[rt.Key()] = make(chan struct{})
+		results[rt.Key()] = &typeResult{clean: true}
+	}
+
+	var wg sync.WaitGroup
+	for _, rt := range deletionOrder {
+		wg.Add(1)
+		go func(rt ResourceType) {
+			defer wg.Done()
+			defer close(done[rt.Key()])
+
+			var blocked []string
+			for _, key := range rt.Dependents() {
+				<-done[key]
+				if !results[key].clean {
+					dep, _ := LookupResourceType(key)
+					blocked = append(blocked, dep.Name())
+				}
+			}
+			if ctx.Err() != nil {
+				results[rt.Key()].clean = false
+				return
+			}
+			*results[rt.Key()] = rt.cleanup(ctx, r, blocked)
+		}(rt)
+	}
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		l.Warn(1, "[취소됨] 남은 삭제 작업을 중단합니다.")
+	}
+	success, fail := 0, 0
+	for _, rt := range deletionOrder {
+		success += results[rt.Key()].success
+		fail += results[rt.Key()].fail
+	}
+	return success, fail
+}
+
+// itemKey identifies a resource across list calls: its id, or its name for
+// types without one (login keys, buckets).
+func (t *resourceType[T]) itemKey(x T) string {
+	name, id := t.identity(x)
+	if id == "" {
+		return name
+	}
+	return id
+}
+
+// describe is the log line announcing the deletion of x.
+func (t *resourceType[T]) describe(x T) string {
+	verb := t.verb
+	if verb == "" {
+		verb = "삭제"
+	}
+	name, id := t.identity(x)
+	if id == "" || id == name {
+		return fmt.Sprintf("%s %s: %s", t.label, verb, name)
+	}
+	return fmt.Sprintf("%s %s: %s (%s)", t.label, verb, name, id)
+}
+
+// cleanup deletes the resources of this type in r.summary, unless blocked
+// names dependents that are not gone yet.
+func (t *resourceType[T]) cleanup(ctx context.Context, r *cleanupRun, blocked []string) typeResult {
+	var items []T
+	for _, x := range *t.field(r.summary) {
+		// Skipped resources (root disks, default ACG/ACL/route tables) are
+		// deleted with their parent.
+		if t.skip == nil || !t.skip(x) {
+			items = append(items, x)
+		}
+	}
+	if len(items) == 0 {
+		return typeResult{clean: true}
+	}
+	l := r.typeLogger(t.key)
+	if len(blocked) > 0 {
+		l.Skip(1, "[건너뜀] %s %d개: 선행 리소스(%s) 삭제가 확인되지 않았습니다", t.name, len(items), strings.Join(blocked, ", "))
+		return typeResult{fail: len(items)}
+	}
+
+	var res typeResult
+	// Re-list first: resources removed since the scan (e.g. servers of a
+	// deleted ASG) count as deleted, and the rest carry their current state.
+	items, gone := t.refresh(ctx, r, items)
+	if gone > 0 {
+		l.OK(1, "%s %d개 이미 삭제됨", t.name, gone)
+		res.success += gone
+	}
+	if len(items) > 0 && t.prepare != nil {
+		t.prepare(r.c, ctx, r.summary, items, l.Phase(progress.PhasePrepare))
+	}
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		deleted []T
+	)
+	for i, x := range items {
+		if !r.acquire(ctx) {
+			mu.Lock()
+			res.fail += len(items) - i
+			mu.Unlock()
+			break
+		}
+		wg.Add(1)
+		go func(x T) {
+			defer wg.Done()
+			defer func() { <-r.sem }()
+
+			name, id := t.identity(x)
+			op := Operation{Region: r.summary.Region, Type: t.key, ID: id, Name: name, Status: OpRequested}
+			r.c.record(op)
+
+			// Buffer the resource's events and emit them as one block.
+			var events []progress.Event
+			buf := progress.SinkFunc(func(e progress.Event) { events = append(events, e) })
+			rl := progress.NewLogger(buf).Resource(t.key, id, name)
+			rl.Info(1, "%s", t.describe(x))
+			err := t.deleteOne(r.c, ctx, x, rl)
+			if err != nil {
+				rl.Fail(2, err, "[실패] %v", err)
+				op.Status, op.Err = OpFailed, err
+			} else {
+				rl.OK(2, "[성공]")
+				op.Status = OpSucceeded
+			}
+			r.c.record(op)
+			r.emit(events...)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				res.fail++
+			} else {
+				deleted = append(deleted, x)
+			}
+		}(x)
+	}
+	wg.Wait()
+
+	remaining := t.waitGone(ctx, r, deleted)
+	res.success += len(deleted) - remaining
+	res.fail += remaining
+	res.clean = res.fail == 0 && ctx.Err() == nil
+	return res
+}
+
+// refresh re-lists the type and returns the items still present (with their
+// current state) and how many are gone. On a list error items are kept as is.
+func (t *resourceType[T]) refresh(ctx context.Context, r *cleanupRun, items []T) ([]T, int) {
+	current, err := t.list(r.c, ctx, r.summary)
+	if err != nil {
+		return items, 0
+	}
+	byKey := make(map[string]T, len(current))
+	for _, x := range current {
+		byKey[t.itemKey(x)] = x
+	}
+	var present []T
+	for _, x := range items {
+		if cur, ok := byKey[t.itemKey(x)]; ok {
+			present = append(present, cur)
+		}
+	}
+	return present, len(items) - len(present)
+}
+
+// waitGone polls the type's list until none of items is listed and returns
+// how many are still listed when it gives up after the type's timeout.
+func (t *resourceType[T]) waitGone(ctx context.Context, r *cleanupRun, items []T) int {
+	if len(items) == 0 {
+		return 0
+	}
+	pending := make(map[string]bool, len(items))
+	for _, x := range items {
+		pending[t.itemKey(x)] = true
+	}
+	spec := waitSpec{what: t.name + " 삭제", timeout: t.timeout}
+	list := func(ctx context.Context) ([]T, error) { return t.list(r.c, ctx, r.summary) }
+	return waitList(ctx, spec, list, func(x T) bool { return pending[t.itemKey(x)] }, r.typeLogger(t.key))
+}
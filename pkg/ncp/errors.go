@@ -0,0 +1,176 @@
+package ncp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// APIError is a non-2xx response from an NCP API.
+type APIError struct {
+	StatusCode    int     // HTTP status
+	ReturnCode    string  // NCP returnCode / errorCode, empty if the body had none
+	ReturnMessage string  // NCP returnMessage / message (+ details)
+	RequestID     string  // requestId, when NCP returned one
+	Service       Service // product the call went to
+	Operation     string  // v2 action (e.g. deleteVpc) or "METHOD /path" for REST APIs
+	Body          string  // raw response body, used when it could not be parsed
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s: HTTP %d", e.Service, e.Operation, e.StatusCode)
+	switch {
+	case e.ReturnCode != "":
+		fmt.Fprintf(&b, " - [%s] %s", e.ReturnCode, e.ReturnMessage)
+	case e.ReturnMessage != "":
+		fmt.Fprintf(&b, " - %s", e.ReturnMessage)
+	case e.Body != "":
+		fmt.Fprintf(&b, " - %s", e.Body)
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, " (requestId=%s)", e.RequestID)
+	}
+	return b.String()
+}
+
+// newAPIError builds an APIError from a failed response, parsing the v2
+// ("responseError"), API gateway ("error") and flat body shapes NCP uses.
+func newAPIError(svc Service, method, path string, status int, body []byte) *APIError {
+	e := &APIError{
+		StatusCode: status,
+		Service:    svc,
+		Operation:  operationName(method, path),
+	}
+
+	type errBody struct {
+		ReturnCode    json.RawMessage `json:"returnCode"`
+		ReturnMessage string          `json:"returnMessage"`
+		ErrorCode     json.RawMessage `json:"errorCode"`
+		Message       string          `json:"message"`
+		Details       string          `json:"details"`
+		RequestID     string          `json:"requestId"`
+	}
+	var parsed struct {
+		errBody
+		ResponseError *errBody `json:"responseError"`
+		Error         *errBody `json:"error"`
+	}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		e.Body = strings.TrimSpace(string(body))
+		return e
+	}
+	eb := &parsed.errBody
+	if parsed.ResponseError != nil {
+		eb = parsed.ResponseError
+	} else if parsed.Error != nil {
+		eb = parsed.Error
+	}
+
+	e.ReturnCode = rawCode(eb.ReturnCode)
+	if e.ReturnCode == "" {
+		e.ReturnCode = rawCode(eb.ErrorCode)
+	}
+	e.ReturnMessage = eb.ReturnMessage
+	if e.ReturnMessage == "" {
+		e.ReturnMessage = eb.Message
+	}
+	if eb.Details != "" {
+		e.ReturnMessage = strings.TrimSpace(e.ReturnMessage + " " + eb.Details)
+	}
+	e.RequestID = eb.RequestID
+	if e.RequestID == "" {
+		e.RequestID = parsed.RequestID
+	}
+	if e.ReturnCode == "" && e.ReturnMessage == "" {
+		e.Body = strings.TrimSpace(string(body))
+	}
+	return e
+}
+
+// rawCode accepts codes sent either as JSON strings or numbers.
+func rawCode(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	return string(raw)
+}
+
+// operationName is the v2 action name for "/action?..." paths and
+// "METHOD /path" for REST-style APIs.
+func operationName(method, path string) string {
+	p, query, _ := strings.Cut(path, "?")
+	if strings.Contains(query, "responseFormatType=") && strings.Count(p, "/") == 1 {
+		return strings.TrimPrefix(p, "/")
+	}
+	return method + " " + p
+}
+
+// dependencyViolationCodes are returnCodes NCP uses when a resource cannot be
+// deleted yet because something still depends on it.
+var dependencyViolationCodes = map[string]bool{
+	"1250600": true, // Auto Scaling Group still has servers
+	"1018005": true, // NAT Gateway is still the target of a route
+}
+
+// permissionDeniedCodes are S3 (Object Storage) error codes meaning the key
+// is not allowed to use the product.
+var permissionDeniedCodes = map[string]bool{
+	"AccessDenied":          true,
+	"InvalidAccessKeyId":    true,
+	"SignatureDoesNotMatch": true,
+}
+
+// AsAPIError returns the APIError in err's chain, if any.
+func AsAPIError(err error) (*APIError, bool) {
+	var e *APIError
+	if errors.As(err, &e) {
+		return e, true
+	}
+	return nil, false
+}
+
+// errorStatus returns the HTTP status and error code carried by err, from an
+// APIError or an Object Storage (S3 SDK) error.
+func errorStatus(err error) (int, string) {
+	if e, ok := AsAPIError(err); ok {
+		return e.StatusCode, e.ReturnCode
+	}
+	status, code := 0, ""
+	var hs interface{ HTTPStatusCode() int }
+	if errors.As(err, &hs) {
+		status = hs.HTTPStatusCode()
+	}
+	var ec interface{ ErrorCode() string }
+	if errors.As(err, &ec) {
+		code = ec.ErrorCode()
+	}
+	return status, code
+}
+
+// IsPermissionDenied reports whether the API key lacks permission for the
+// product (or the product is not enabled for the account).
+func IsPermissionDenied(err error) bool {
+	status, code := errorStatus(err)
+	return status == http.StatusUnauthorized || status == http.StatusForbidden || permissionDeniedCodes[code]
+}
+
+// IsNotFound reports whether the API or resource does not exist, e.g. a
+// product that is unavailable on this account/platform.
+func IsNotFound(err error) bool {
+	status, code := errorStatus(err)
+	return status == http.StatusNotFound || code == "NoSuchBucket"
+}
+
+// IsDependencyViolation reports whether a delete was rejected because other
+// resources still depend on the target; retrying later may succeed.
+func IsDependencyViolation(err error) bool {
+	status, code := errorStatus(err)
+	return dependencyViolationCodes[code] || code == "BucketNotEmpty" || status == http.StatusConflict
+}
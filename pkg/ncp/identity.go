@@ -0,0 +1,40 @@
+package ncp
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// ContractSummary is one row of the Billing API's getContractSummaryList.
+type ContractSummary struct {
+	MemberNo      string `json:"memberNo"`
+	ContractMonth string `json:"contractMonth"`
+}
+
+// MemberNo returns the NCP member number of the account the client's access
+// key belongs to, read from its contracts of this or last month (Billing
+// API). The key needs permission to read billing information, and an
+// account without contracts in those two months cannot be identified: NCP
+// has no other API that returns the member number.
+func (c *Client) MemberNo(ctx context.Context) (string, error) {
+	// From the first of the month: AddDate normalizes, so Mar 31 minus a
+	// month would be Mar 3.
+	now := time.Now()
+	first := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	for _, month := range []time.Time{first, first.AddDate(0, -1, 0)} {
+		params := url.Values{"contractMonth": {month.Format("200601")}}
+		rows, err := listAll[ContractSummary](ctx, c, ServiceBilling, "getContractSummaryList", "contractSummaryList", params)
+		if err != nil {
+			return "", fmt.Errorf("회원 번호 조회: %w", err)
+		}
+		for _, r := range rows {
+			if r.MemberNo != "" {
+				return r.MemberNo, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("회원 번호 조회: %s·%s 계약 내역이 없어 회원 번호를 알 수 없습니다 (회원 번호는 Billing 계약 내역에서만 읽습니다)",
+		first.Format("200601"), first.AddDate(0, -1, 0).Format("200601"))
+}
@@ -0,0 +1,22 @@
+package ncp_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestMemberNo(t *testing.T) {
+	_, acct, c := newTestClient(t)
+	ctx := context.Background()
+
+	// Without contracts there is nothing to read the member number from.
+	if _, err := c.MemberNo(ctx); err == nil || !strings.Contains(err.Error(), "계약 내역이 없어") {
+		t.Errorf("account without contracts: err = %v, want the missing contracts named", err)
+	}
+
+	acct.SetMemberNo("12345")
+	if no, err := c.MemberNo(ctx); no != "12345" || err != nil {
+		t.Errorf("MemberNo = %q, %v, want 12345", no, err)
+	}
+}
@@ -0,0 +1,137 @@
+package ncptest
+
+import "ncp-nuke/pkg/ncp"
+
+// Kind names a collection of fake resources held per account.
+type Kind string
+
+const (
+	Servers               Kind = "servers"
+	BlockStorages         Kind = "block_storages"
+	BlockStorageSnapshots Kind = "block_storage_snapshots"
+	PublicIps             Kind = "public_ips"
+	NasVolumes            Kind = "nas_volumes"
+	NasVolumeSnapshots    Kind = "nas_volume_snapshots"
+	LoadBalancers         Kind = "load_balancers"
+	TargetGroups          Kind = "target_groups"
+	CloudDBs              Kind = "cloud_dbs"
+	CloudPostgresqls      Kind = "cloud_postgresqls"
+	CloudMongoDBs         Kind = "cloud_mongodbs"
+	CloudMariaDBs         Kind = "cloud_mariadbs"
+	CloudMySQLs           Kind = "cloud_mysqls"
+	CloudRedises          Kind = "cloud_redises"
+	Vpcs                  Kind = "vpcs"
+	Subnets               Kind = "subnets"
+	NatGateways           Kind = "nat_gateways"
+	VpcPeerings           Kind = "vpc_peerings"
+	NetworkAcls           Kind = "network_acls"
+	RouteTables           Kind = "route_tables"
+	AccessControlGroups   Kind = "access_control_groups"
+	AutoScalingGroups     Kind = "auto_scaling_groups"
+	LaunchConfigurations  Kind = "launch_configurations"
+	InitScripts           Kind = "init_scripts"
+	LoginKeys             Kind = "login_keys"
+	PlacementGroups       Kind = "placement_groups"
+	NksClusters           Kind = "nks_clusters"
+	ApiGatewayProducts    Kind = "api_gateway_products"
+	SubAccounts           Kind = "sub_accounts"
+	Buckets               Kind = "buckets"
+	Regions               Kind = "regions"
+	InstanceTags          Kind = "instance_tags" // ncp.InstanceTag
+	Contracts             Kind = "contracts"     // ncp.ContractSummary (see Account.SetMemberNo)
+
+	// Classic platform (only served for accounts with EnableClassic).
+	ClassicServers       Kind = "classic_servers"
+	ClassicBlockStorages Kind = "classic_block_storages"
+	ClassicPublicIps     Kind = "classic_public_ips"
+	ClassicNasVolumes    Kind = "classic_nas_volumes"
+	ClassicLoadBalancers Kind = "classic_load_balancers"
+	ClassicInstanceTags  Kind = "classic_instance_tags"
+)
+
+// collection describes how an NCP v2 "get*List" style API exposes a Kind.
+type collection struct {
+	kind    Kind
+	service ncp.Service
+	list    string // list action, e.g. getServerInstanceList
+	listKey string // JSON key of the list inside the response
+	idField string // JSON field holding the resource id
+	delete  string // delete action
+	// deleteParam is the query parameter carrying the id(s). A ".N" suffix
+	// means an indexed list (e.g. serverInstanceNoList.1, .2, ...).
+	deleteParam string
+}
+
+var collections = []collection{
+	{Servers, ncp.ServiceVServer, "getServerInstanceList", "serverInstanceList", "serverInstanceNo", "terminateServerInstances", "serverInstanceNoList.N"},
+	{BlockStorages, ncp.ServiceVServer, "getBlockStorageInstanceList", "blockStorageInstanceList", "blockStorageInstanceNo", "deleteBlockStorageInstances", "blockStorageInstanceNoList.N"},
+	{BlockStorageSnapshots, ncp.ServiceVServer, "getBlockStorageSnapshotInstanceList", "blockStorageSnapshotInstanceList", "blockStorageSnapshotInstanceNo", "deleteBlockStorageSnapshotInstances", "blockStorageSnapshotInstanceNoList.N"},
+	{PublicIps, ncp.ServiceVServer, "getPublicIpInstanceList", "publicIpInstanceList", "publicIpInstanceNo", "deletePublicIpInstance", "publicIpInstanceNo"},
+	{AccessControlGroups, ncp.ServiceVServer, "getAccessControlGroupList", "accessControlGroupList", "accessControlGroupNo", "deleteAccessControlGroup", "accessControlGroupNo"},
+	{InitScripts, ncp.ServiceVServer, "getInitScriptList", "initScriptList", "initScriptNo", "deleteInitScripts", "initScriptNoList.N"},
+	{LoginKeys, ncp.ServiceVServer, "getLoginKeyList", "loginKeyList", "keyName", "deleteLoginKeys", "keyNameList.N"},
+	{PlacementGroups, ncp.ServiceVServer, "getPlacementGroupList", "placementGroupList", "placementGroupNo", "deletePlacementGroup", "placementGroupNo"},
+	{NasVolumes, ncp.ServiceVNAS, "getNasVolumeInstanceList", "nasVolumeInstanceList", "nasVolumeInstanceNo", "deleteNasVolumeInstance", "nasVolumeInstanceNo"},
+	{NasVolumeSnapshots, ncp.ServiceVNAS, "getNasVolumeSnapshotList", "nasVolumeSnapshotList", "nasVolumeSnapshotInstanceNo", "deleteNasVolumeSnapshot", "nasVolumeSnapshotInstanceNo"},
+	{LoadBalancers, ncp.ServiceVLB, "getLoadBalancerInstanceList", "loadBalancerInstanceList", "loadBalancerInstanceNo", "deleteLoadBalancerInstances", "loadBalancerInstanceNoList.N"},
+	{TargetGroups, ncp.ServiceVLB, "getTargetGroupList", "targetGroupList", "targetGroupNo", "deleteTargetGroups", "targetGroupNoList.N"},
+	{CloudDBs, ncp.ServiceCloudDB, "getCloudDBInstanceList", "cloudDBInstanceList", "cloudDBInstanceNo", "deleteCloudDBServerInstance", "cloudDBInstanceNo"},
+	{CloudPostgresqls, ncp.ServiceVPostgreSQL, "getCloudPostgresqlInstanceList", "cloudPostgresqlInstanceList", "cloudPostgresqlInstanceNo", "deleteCloudPostgresqlInstance", "cloudPostgresqlInstanceNo"},
+	{CloudMongoDBs, ncp.ServiceVMongoDB, "getCloudMongoDbInstanceList", "cloudMongoDbInstanceList", "cloudMongoDbInstanceNo", "deleteCloudMongoDbInstance", "cloudMongoDbInstanceNo"},
+	{CloudMariaDBs, ncp.ServiceVMariaDB, "getCloudMariaDbInstanceList", "cloudMariaDbInstanceList", "cloudMariaDbInstanceNo", "deleteCloudMariaDbInstance", "cloudMariaDbInstanceNo"},
+	{CloudMySQLs, ncp.ServiceVMySQL, "getCloudMysqlInstanceList", "cloudMysqlInstanceList", "cloudMysqlInstanceNo", "deleteCloudMysqlInstance", "cloudMysqlInstanceNo"},
+	{CloudRedises, ncp.ServiceVRedis, "getCloudRedisInstanceList", "cloudRedisInstanceList", "cloudRedisInstanceNo", "deleteCloudRedisInstance", "cloudRedisInstanceNo"},
+	{Vpcs, ncp.ServiceVPC, "getVpcList", "vpcList", "vpcNo", "deleteVpc", "vpcNo"},
+	{Subnets, ncp.ServiceVPC, "getSubnetList", "subnetList", "subnetNo", "deleteSubnet", "subnetNo"},
+	{NatGateways, ncp.ServiceVPC, "getNatGatewayInstanceList", "natGatewayInstanceList", "natGatewayInstanceNo", "deleteNatGatewayInstance", "natGatewayInstanceNo"},
+	{VpcPeerings, ncp.ServiceVPC, "getVpcPeeringInstanceList", "vpcPeeringInstanceList", "vpcPeeringInstanceNo", "deleteVpcPeeringInstance", "vpcPeeringInstanceNo"},
+	{NetworkAcls, ncp.ServiceVPC, "getNetworkAclList", "networkAclList", "networkAclNo", "deleteNetworkAcl", "networkAclNo"},
+	{RouteTables, ncp.ServiceVPC, "getRouteTableList", "routeTableList", "routeTableNo", "deleteRouteTable", "routeTableNo"},
+	{Regions, ncp.ServiceVServer, "getRegionList", "regionList", "regionCode", "", ""},
+	{InstanceTags, ncp.ServiceVServer, "getInstanceTagList", "instanceTagList", "instanceNo", "", ""},
+	{AutoScalingGroups, ncp.ServiceVAutoScaling, "getAutoScalingGroupList", "autoScalingGroupList", "autoScalingGroupNo", "deleteAutoScalingGroup", "autoScalingGroupNo"},
+	{LaunchConfigurations, ncp.ServiceVAutoScaling, "getLaunchConfigurationList", "launchConfigurationList", "launchConfigurationNo", "deleteLaunchConfiguration", "launchConfigurationNo"},
+	{ClassicServers, ncp.ServiceServer, "getServerInstanceList", "serverInstanceList", "serverInstanceNo", "terminateServerInstances", "serverInstanceNoList.N"},
+	{ClassicBlockStorages, ncp.ServiceServer, "getBlockStorageInstanceList", "blockStorageInstanceList", "blockStorageInstanceNo", "deleteBlockStorageInstances", "blockStorageInstanceNoList.N"},
+	{ClassicPublicIps, ncp.ServiceServer, "getPublicIpInstanceList", "publicIpInstanceList", "publicIpInstanceNo", "deletePublicIpInstances", "publicIpInstanceNoList.N"},
+	{ClassicNasVolumes, ncp.ServiceServer, "getNasVolumeInstanceList", "nasVolumeInstanceList", "nasVolumeInstanceNo", "deleteNasVolumeInstance", "nasVolumeInstanceNo"},
+	{Regions, ncp.ServiceServer, "getRegionList", "regionList", "regionCode", "", ""},
+	{ClassicInstanceTags, ncp.ServiceServer, "getInstanceTagList", "instanceTagList", "instanceNo", "", ""},
+	{Contracts, ncp.ServiceBilling, "getContractSummaryList", "contractSummaryList", "memberNo", "", ""},
+	{ClassicLoadBalancers, ncp.ServiceLoadBalancer, "getLoadBalancerInstanceList", "loadBalancerInstanceList", "loadBalancerInstanceNo", "deleteLoadBalancerInstances", "loadBalancerInstanceNoList.N"},
+}
+
+// restKinds are exposed through REST-style APIs rather than get*List actions.
+var restKinds = map[Kind]string{
+	NksClusters:        "uuid",
+	ApiGatewayProducts: "productId",
+	SubAccounts:        "subAccountId",
+	Buckets:            "name",
+}
+
+func collectionByAction(svc ncp.Service, action string) (*collection, bool) {
+	for i := range collections {
+		c := &collections[i]
+		if c.service == svc && (c.list == action || c.delete != "" && c.delete == action) {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+func collectionByKind(kind Kind) (*collection, bool) {
+	for i := range collections {
+		if collections[i].kind == kind {
+			return &collections[i], true
+		}
+	}
+	return nil, false
+}
+
+// idField returns the JSON id field of any Kind.
+func idField(kind Kind) string {
+	if c, ok := collectionByKind(kind); ok {
+		return c.idField
+	}
+	return restKinds[kind]
+}
@@ -0,0 +1,163 @@
+package ncptest
+
+import (
+	"encoding/xml"
+	"net/http"
+	"strings"
+)
+
+// The Object Storage fake speaks just enough path-style S3 for ListBuckets
+// and DeleteBucket: list buckets, list object versions, list multipart
+// uploads, multi-object delete and bucket delete. Requests are attributed to
+// an account by the access key in the SigV4 Authorization header; the
+// signature itself is not verified.
+
+// SeedBucket adds a KR-region bucket holding the given object keys.
+func (a *Account) SeedBucket(name string, keys ...string) {
+	a.SeedBucketIn("kr-standard", name, keys...)
+}
+
+// SeedBucketIn adds a bucket in the given S3 signing region (e.g.
+// "sg-standard"); ListBuckets only returns buckets of the request's region.
+func (a *Account) SeedBucketIn(region, name string, keys ...string) {
+	a.srv.mu.Lock()
+	defer a.srv.mu.Unlock()
+	objects := make([]any, 0, len(keys))
+	for _, k := range keys {
+		objects = append(objects, k)
+	}
+	a.data[Buckets] = append(a.data[Buckets], map[string]any{"name": name, "region": region, "objects": objects})
+}
+
+// s3Account resolves the account from "Credential=<accessKey>/..." in the
+// Authorization header.
+func (s *Server) s3Account(r *http.Request) *Account {
+	accessKey, _ := s3Credential(r)
+	return s.accounts[accessKey]
+}
+
+// s3Credential returns the access key and signing region from
+// "Credential=<accessKey>/<date>/<region>/s3/aws4_request".
+func s3Credential(r *http.Request) (accessKey, region string) {
+	_, cred, ok := strings.Cut(r.Header.Get("Authorization"), "Credential=")
+	if !ok {
+		return "", ""
+	}
+	cred, _, _ = strings.Cut(cred, ",")
+	parts := strings.Split(cred, "/")
+	if len(parts) > 2 {
+		region = parts[2]
+	}
+	return parts[0], region
+}
+
+func (a *Account) serveObjectStorage(w http.ResponseWriter, r *http.Request, path string) {
+	name := strings.Trim(path, "/")
+	q := r.URL.Query()
+
+	if name == "" {
+		type bucket struct {
+			Name string `xml:"Name"`
+		}
+		var out struct {
+			XMLName xml.Name `xml:"ListAllMyBucketsResult"`
+			Buckets []bucket `xml:"Buckets>Bucket"`
+		}
+		_, region := s3Credential(r)
+		for _, b := range a.data[Buckets] {
+			if str(b["region"]) != region {
+				continue
+			}
+			out.Buckets = append(out.Buckets, bucket{Name: str(b["name"])})
+		}
+		writeXML(w, http.StatusOK, out)
+		return
+	}
+
+	buckets := a.matching(Buckets, []string{name})
+	if len(buckets) == 0 {
+		writeS3Error(w, http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist.")
+		return
+	}
+	b := buckets[0]
+
+	switch {
+	case r.Method == http.MethodGet && q.Has("versions"):
+		type version struct {
+			Key       string `xml:"Key"`
+			VersionId string `xml:"VersionId"`
+		}
+		var out struct {
+			XMLName  xml.Name  `xml:"ListVersionsResult"`
+			Name     string    `xml:"Name"`
+			Versions []version `xml:"Version"`
+		}
+		out.Name = name
+		for _, k := range bucketObjects(b) {
+			out.Versions = append(out.Versions, version{Key: k, VersionId: "null"})
+		}
+		writeXML(w, http.StatusOK, out)
+	case r.Method == http.MethodGet && q.Has("uploads"):
+		writeXML(w, http.StatusOK, struct {
+			XMLName xml.Name `xml:"ListMultipartUploadsResult"`
+			Bucket  string   `xml:"Bucket"`
+		}{Bucket: name})
+	case r.Method == http.MethodPost && q.Has("delete"):
+		var req struct {
+			Objects []struct {
+				Key string `xml:"Key"`
+			} `xml:"Object"`
+		}
+		if err := xml.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeS3Error(w, http.StatusBadRequest, "MalformedXML", err.Error())
+			return
+		}
+		drop := map[string]bool{}
+		for _, o := range req.Objects {
+			drop[o.Key] = true
+		}
+		var keep []any
+		for _, k := range bucketObjects(b) {
+			if !drop[k] {
+				keep = append(keep, k)
+			}
+		}
+		b["objects"] = keep
+		writeXML(w, http.StatusOK, struct {
+			XMLName xml.Name `xml:"DeleteResult"`
+		}{})
+	case r.Method == http.MethodDelete:
+		if len(bucketObjects(b)) > 0 {
+			writeS3Error(w, http.StatusConflict, "BucketNotEmpty", "The bucket you tried to delete is not empty.")
+			return
+		}
+		a.remove(Buckets, name)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeS3Error(w, http.StatusNotImplemented, "NotImplemented", "ncptest does not implement this operation.")
+	}
+}
+
+func bucketObjects(b map[string]any) []string {
+	raw, _ := b["objects"].([]any)
+	keys := make([]string, 0, len(raw))
+	for _, k := range raw {
+		keys = append(keys, str(k))
+	}
+	return keys
+}
+
+func writeXML(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	w.Write([]byte(xml.Header))
+	xml.NewEncoder(w).Encode(v)
+}
+
+func writeS3Error(w http.ResponseWriter, status int, code, msg string) {
+	writeXML(w, status, struct {
+		XMLName xml.Name `xml:"Error"`
+		Code    string   `xml:"Code"`
+		Message string   `xml:"Message"`
+	}{Code: code, Message: msg})
+}
@@ -0,0 +1,691 @@
+// Package ncptest provides an in-memory fake of the NCP APIs used by ncp-nuke,
+// so ListAllResources and CleanupAllResources can run end-to-end without a
+// real account.
+//
+// A single httptest server hosts every product under its own path prefix
+// (e.g. /vserver, /vpc, /subaccount). State is kept per access key, and every
+// request must carry a valid x-ncp-apigw-signature-v2 header, exactly as the
+// real API gateway requires.
+//
+//	srv := ncptest.NewServer()
+//	defer srv.Close()
+//	acct := srv.AddAccount("AK", "SK")
+//	acct.Seed(ncptest.Servers, ncp.ServerInstance{ServerInstanceNo: "1", ServerName: "web"})
+//	client := ncp.NewClient("AK", "SK", srv.ClientOptions()...)
+package ncptest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"ncp-nuke/pkg/ncp"
+)
+
+// Server is a stateful fake NCP API server.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	accounts map[string]*Account // access key -> account
+	requests []string            // "<service> <METHOD> <action>" in arrival order
+	failures []int               // statuses to return for the next requests
+}
+
+// Account holds the fake resources owned by one access key.
+type Account struct {
+	srv       *Server
+	accessKey string
+	secretKey string
+	classic   bool // whether Classic platform APIs are available
+	data      map[Kind][]map[string]any
+}
+
+// NewServer starts a fake NCP server. Call Close when done.
+func NewServer() *Server {
+	s := &Server{accounts: map[string]*Account{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// AddAccount registers credentials and returns the (empty) account state.
+func (s *Server) AddAccount(accessKey, secretKey string) *Account {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	a := &Account{srv: s, accessKey: accessKey, secretKey: secretKey, data: map[Kind][]map[string]any{}}
+	s.accounts[accessKey] = a
+	return a
+}
+
+// Endpoints returns base URLs pointing every service at this server.
+func (s *Server) Endpoints() map[ncp.Service]string {
+	m := map[ncp.Service]string{}
+	for _, svc := range ncp.Services() {
+		m[svc] = s.URL + "/" + string(svc)
+	}
+	return m
+}
+
+// ClientOptions returns the options that point an ncp.Client at this server.
+func (s *Server) ClientOptions() []ncp.Option {
+	return []ncp.Option{ncp.WithEndpoints(s.Endpoints()), ncp.WithHTTPClient(s.Client())}
+}
+
+// Requests returns every request served so far as "<service> <METHOD> <action>".
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+// FailNext makes the next n requests fail with status (e.g. 429 or 503)
+// before they reach the fake, to exercise client retries.
+func (s *Server) FailNext(n, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for range n {
+		s.failures = append(s.failures, status)
+	}
+}
+
+// Seed adds resources of the given kind. Items are usually ncp structs (e.g.
+// ncp.ServerInstance); they are stored by their JSON representation.
+func (a *Account) Seed(kind Kind, items ...any) {
+	a.srv.mu.Lock()
+	defer a.srv.mu.Unlock()
+	for _, it := range items {
+		b, err := json.Marshal(it)
+		if err != nil {
+			panic(fmt.Sprintf("ncptest: seeding %s: %v", kind, err))
+		}
+		var m map[string]any
+		if err := json.Unmarshal(b, &m); err != nil {
+			panic(fmt.Sprintf("ncptest: seeding %s: %v", kind, err))
+		}
+		a.data[kind] = append(a.data[kind], m)
+	}
+}
+
+// EnableClassic makes the Classic platform APIs (server/v2, loadbalancer/v2)
+// available; by default they are rejected like on a VPC-only account.
+func (a *Account) EnableClassic() {
+	a.srv.mu.Lock()
+	defer a.srv.mu.Unlock()
+	a.classic = true
+}
+
+// SetMemberNo gives the account a contract of the current month, from which
+// ncp.Client.MemberNo reads its member number.
+func (a *Account) SetMemberNo(memberNo string) {
+	a.Seed(Contracts, ncp.ContractSummary{MemberNo: memberNo, ContractMonth: time.Now().Format("200601")})
+}
+
+// Count returns how many resources of kind the account still has.
+func (a *Account) Count(kind Kind) int {
+	a.srv.mu.Lock()
+	defer a.srv.mu.Unlock()
+	return len(a.data[kind])
+}
+
+// IDs returns the ids of the remaining resources of kind.
+func (a *Account) IDs(kind Kind) []string {
+	a.srv.mu.Lock()
+	defer a.srv.mu.Unlock()
+	var ids []string
+	for _, it := range a.data[kind] {
+		ids = append(ids, str(it[idField(kind)]))
+	}
+	return ids
+}
+
+// Total returns the number of resources across all kinds (sub accounts,
+// regions, tags and contracts excluded).
+func (a *Account) Total() int {
+	a.srv.mu.Lock()
+	defer a.srv.mu.Unlock()
+	n := 0
+	for k, items := range a.data {
+		if k != SubAccounts && k != Regions && k != InstanceTags && k != ClassicInstanceTags && k != Contracts {
+			n += len(items)
+		}
+	}
+	return n
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	rest := strings.TrimPrefix(r.URL.Path, "/")
+	svcName, path, _ := strings.Cut(rest, "/")
+	svc := ncp.Service(svcName)
+	path = "/" + path
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, fmt.Sprintf("%s %s %s", svc, r.Method, path))
+
+	if len(s.failures) > 0 {
+		status := s.failures[0]
+		s.failures = s.failures[1:]
+		writeGatewayError(w, status, strconv.Itoa(status), http.StatusText(status), "injected by ncptest")
+		return
+	}
+
+	if svc == ncp.ServiceObjectStorage {
+		acct := s.s3Account(r)
+		if acct == nil {
+			writeS3Error(w, http.StatusForbidden, "InvalidAccessKeyId", "unknown access key")
+			return
+		}
+		acct.serveObjectStorage(w, r, path)
+		return
+	}
+
+	acct, ok := s.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	switch svc {
+	case ncp.ServiceSubAccount:
+		acct.serveSubAccount(w, r, path)
+	case ncp.ServiceVNKS:
+		acct.serveNks(w, r, path)
+	case ncp.ServiceAPIGateway:
+		acct.serveApiGateway(w, r, path)
+	case ncp.ServiceServer, ncp.ServiceLoadBalancer:
+		if !acct.classic {
+			writeGatewayError(w, http.StatusForbidden, "800", "Forbidden", "This account cannot use the Classic platform.")
+			return
+		}
+		acct.serveAction(w, r, svc, strings.TrimPrefix(path, "/"))
+	default:
+		acct.serveAction(w, r, svc, strings.TrimPrefix(path, "/"))
+	}
+}
+
+// authenticate verifies the NCP API gateway headers the same way the real
+// gateway does: known access key, fresh timestamp and a matching HMAC.
+func (s *Server) authenticate(w http.ResponseWriter, r *http.Request) (*Account, bool) {
+	accessKey := r.Header.Get("x-ncp-iam-access-key")
+	timestamp := r.Header.Get("x-ncp-apigw-timestamp")
+	signature := r.Header.Get("x-ncp-apigw-signature-v2")
+
+	acct, ok := s.accounts[accessKey]
+	if !ok || timestamp == "" || signature == "" {
+		writeGatewayError(w, http.StatusUnauthorized, "200", "Authentication Failed", "Invalid authentication information.")
+		return nil, false
+	}
+	ms, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || time.Since(time.UnixMilli(ms)).Abs() > 5*time.Minute {
+		writeGatewayError(w, http.StatusUnauthorized, "210", "Authentication Failed", "Timestamp is expired.")
+		return nil, false
+	}
+	want := ncp.MakeSignature(acct.secretKey, r.Method, r.RequestURI, timestamp, accessKey)
+	if signature != want {
+		writeGatewayError(w, http.StatusUnauthorized, "200", "Authentication Failed", "Signature mismatch.")
+		return nil, false
+	}
+	return acct, true
+}
+
+// serveAction handles the NCP v2 "?action&params" style APIs.
+func (a *Account) serveAction(w http.ResponseWriter, r *http.Request, svc ncp.Service, action string) {
+	q := r.URL.Query()
+
+	switch {
+	case (svc == ncp.ServiceVServer || svc == ncp.ServiceServer) && action == "stopServerInstances":
+		kind := Servers
+		if svc == ncp.ServiceServer {
+			kind = ClassicServers
+		}
+		for _, it := range a.matching(kind, indexed(q, "serverInstanceNoList")) {
+			it["serverInstanceStatus"] = map[string]any{"code": "NSTOP", "codeName": "Server NSTOP state"}
+		}
+		writeOK(w, action)
+		return
+	case svc == ncp.ServiceVServer && action == "setProtectServerTermination",
+		svc == ncp.ServiceVAutoScaling && action == "updateAutoScalingGroup" && q.Get("desiredCapacity") == "":
+		writeOK(w, action)
+		return
+	case svc == ncp.ServiceVAutoScaling && action == "updateAutoScalingGroup":
+		for _, it := range a.matching(AutoScalingGroups, []string{q.Get("autoScalingGroupNo")}) {
+			it["desiredCapacity"], _ = strconv.Atoi(q.Get("desiredCapacity"))
+		}
+		writeOK(w, action)
+		return
+	case svc == ncp.ServiceVServer && action == "disassociatePublicIpFromServerInstance":
+		for _, it := range a.matching(PublicIps, []string{q.Get("publicIpInstanceNo")}) {
+			it["serverInstanceNo"] = ""
+			it["serverName"] = ""
+		}
+		writeOK(w, action)
+		return
+	case svc == ncp.ServiceServer && action == "disassociatePublicIpFromServerInstance":
+		for _, it := range a.matching(ClassicPublicIps, []string{q.Get("publicIpInstanceNo")}) {
+			delete(it, "serverInstance")
+		}
+		writeOK(w, action)
+		return
+	case svc == ncp.ServiceVPC && action == "getRouteList":
+		a.serveRouteList(w, q)
+		return
+	case svc == ncp.ServiceVPC && action == "removeRoute":
+		a.serveRemoveRoute(w, q)
+		return
+	}
+
+	c, ok := collectionByAction(svc, action)
+	if !ok {
+		writeGatewayError(w, http.StatusNotFound, "300", "Not Found Exception", "URL not found.")
+		return
+	}
+	if action == c.list {
+		a.serveList(w, c, action, q)
+		return
+	}
+	a.serveDelete(w, c, action, q)
+}
+
+// controlParams are list parameters that never filter by field.
+var controlParams = map[string]bool{
+	"responseFormatType": true, "pageNo": true, "pageSize": true, "regionCode": true, "zoneCode": true,
+}
+
+// defaultRegions is what getRegionList returns when no Regions were seeded.
+var defaultRegions = []map[string]any{{"regionNo": "1", "regionCode": ncp.DefaultRegion, "regionName": "Korea"}}
+
+func (a *Account) serveList(w http.ResponseWriter, c *collection, action string, q map[string][]string) {
+	items := a.data[c.kind]
+	if c.kind == Regions && len(items) == 0 {
+		items = defaultRegions
+	}
+	var out []map[string]any
+	for _, it := range items {
+		if matchesFilters(it, q) && (c.kind == Regions || inRegion(it, first(q["regionCode"]))) {
+			out = append(out, it)
+		}
+	}
+	total := len(out)
+
+	pageSize, _ := strconv.Atoi(first(q["pageSize"]))
+	pageNo, _ := strconv.Atoi(first(q["pageNo"]))
+	if pageSize > 0 {
+		if pageNo < 1 {
+			pageNo = 1
+		}
+		start := min((pageNo-1)*pageSize, total)
+		out = out[start:min(start+pageSize, total)]
+	}
+	if out == nil {
+		out = []map[string]any{}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		action + "Response": map[string]any{
+			"requestId":     newRequestID(),
+			"returnCode":    "0",
+			"returnMessage": "success",
+			"totalRows":     total,
+			c.listKey:       out,
+		},
+	})
+}
+
+// inRegion reports whether an item belongs to the requested region. Items
+// seeded without a regionCode live in the default region (KR), which is also
+// the region of requests that send none.
+func inRegion(it map[string]any, region string) bool {
+	if region == "" {
+		region = ncp.DefaultRegion
+	}
+	own := str(it["regionCode"])
+	if own == "" {
+		own = ncp.DefaultRegion
+	}
+	return own == region
+}
+
+// matchesFilters applies list filters: "<field>" equality and
+// "<field>List.N" membership, ignoring control parameters.
+func matchesFilters(it map[string]any, q map[string][]string) bool {
+	lists := map[string][]string{}
+	for k, v := range q {
+		if controlParams[k] || len(v) == 0 {
+			continue
+		}
+		if base, _, ok := strings.Cut(k, "List."); ok {
+			lists[base] = append(lists[base], v[0])
+			continue
+		}
+		if f, ok := it[k]; ok && str(f) != v[0] {
+			return false
+		}
+	}
+	for field, want := range lists {
+		f, ok := it[field]
+		if !ok {
+			continue
+		}
+		found := false
+		for _, v := range want {
+			if str(f) == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func (a *Account) serveDelete(w http.ResponseWriter, c *collection, action string, q map[string][]string) {
+	var ids []string
+	if base, ok := strings.CutSuffix(c.deleteParam, ".N"); ok {
+		ids = indexed(q, base)
+		if len(ids) == 0 {
+			// Some callers send the singular form (e.g. loadBalancerInstanceNo).
+			ids = []string{first(q[strings.TrimSuffix(base, "List")])}
+		}
+	} else {
+		ids = []string{first(q[c.deleteParam])}
+	}
+
+	for _, id := range ids {
+		if id == "" || len(a.matching(c.kind, []string{id})) == 0 {
+			writeActionError(w, action, "1000", fmt.Sprintf("%s %q does not exist.", c.idField, id))
+			return
+		}
+		if code, msg := a.dependencyError(c.kind, id); code != "" {
+			writeActionError(w, action, code, msg)
+			return
+		}
+	}
+	for _, id := range ids {
+		a.remove(c.kind, id)
+		switch c.kind {
+		case Servers:
+			a.removeBasicStorages(BlockStorages, "blockStorageDiskDetailType", id)
+		case ClassicServers:
+			a.removeBasicStorages(ClassicBlockStorages, "blockStorageType", id)
+		}
+	}
+	writeOK(w, action)
+}
+
+// dependencyError reproduces the NCP rejections ncp-nuke has to work around.
+func (a *Account) dependencyError(kind Kind, id string) (string, string) {
+	switch kind {
+	case NatGateways:
+		for _, rt := range a.data[RouteTables] {
+			for _, route := range routesOf(rt) {
+				if str(route["targetNo"]) == id {
+					return "1018005", "NAT Gateway is in use by a route table."
+				}
+			}
+		}
+	case AutoScalingGroups:
+		for _, it := range a.matching(AutoScalingGroups, []string{id}) {
+			if n, _ := it["desiredCapacity"].(int); n > 0 {
+				return "1250600", "Auto Scaling Group still has server instances."
+			}
+		}
+	}
+	return "", ""
+}
+
+// removeBasicStorages drops the root disks that NCP deletes with a server.
+// typeField is the code field marking a root disk as BASIC.
+func (a *Account) removeBasicStorages(kind Kind, typeField, serverNo string) {
+	var keep []map[string]any
+	for _, bs := range a.data[kind] {
+		detail, _ := bs[typeField].(map[string]any)
+		if str(bs["serverInstanceNo"]) == serverNo && str(detail["code"]) == "BASIC" {
+			continue
+		}
+		keep = append(keep, bs)
+	}
+	a.data[kind] = keep
+}
+
+func (a *Account) serveRouteList(w http.ResponseWriter, q map[string][]string) {
+	var routes []map[string]any
+	for _, rt := range a.matching(RouteTables, []string{first(q["routeTableNo"])}) {
+		routes = routesOf(rt)
+	}
+	if routes == nil {
+		routes = []map[string]any{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"getRouteListResponse": map[string]any{
+			"requestId": newRequestID(), "returnCode": "0", "returnMessage": "success",
+			"totalRows": len(routes), "routeList": routes,
+		},
+	})
+}
+
+func (a *Account) serveRemoveRoute(w http.ResponseWriter, q map[string][]string) {
+	tables := a.matching(RouteTables, []string{first(q["routeTableNo"])})
+	if len(tables) == 0 {
+		writeActionError(w, "removeRoute", "1000", "route table does not exist.")
+		return
+	}
+	dest := first(q["routeList.1.destinationCidrBlock"])
+	target := first(q["routeList.1.targetNo"])
+	var keep []any
+	for _, route := range routesOf(tables[0]) {
+		if str(route["destinationCidrBlock"]) == dest && str(route["targetNo"]) == target {
+			continue
+		}
+		keep = append(keep, route)
+	}
+	tables[0]["routeList"] = keep
+	writeOK(w, "removeRoute")
+}
+
+func (a *Account) serveNks(w http.ResponseWriter, r *http.Request, path string) {
+	switch {
+	case r.Method == http.MethodGet && path == "/clusters":
+		var clusters []map[string]any
+		for _, it := range a.data[NksClusters] {
+			if inRegion(it, r.Header.Get("x-ncp-region_code")) {
+				clusters = append(clusters, it)
+			}
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"clusters": nonNil(clusters)})
+	case r.Method == http.MethodDelete && strings.HasPrefix(path, "/clusters/"):
+		uuid := strings.TrimPrefix(path, "/clusters/")
+		if !a.remove(NksClusters, uuid) {
+			writeGatewayError(w, http.StatusNotFound, "404", "Not Found", "cluster not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"uuid": uuid})
+	default:
+		writeGatewayError(w, http.StatusNotFound, "300", "Not Found Exception", "URL not found.")
+	}
+}
+
+func (a *Account) serveApiGateway(w http.ResponseWriter, r *http.Request, path string) {
+	switch {
+	case r.Method == http.MethodGet && path == "/products":
+		items := nonNil(a.data[ApiGatewayProducts])
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		start := min(offset, len(items))
+		end := len(items)
+		if limit > 0 {
+			end = min(start+limit, len(items))
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"products": items[start:end], "total": len(items)})
+	case r.Method == http.MethodDelete && strings.HasPrefix(path, "/products/"):
+		if !a.remove(ApiGatewayProducts, strings.TrimPrefix(path, "/products/")) {
+			writeGatewayError(w, http.StatusNotFound, "10001", "Not Found", "product not found")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeGatewayError(w, http.StatusNotFound, "300", "Not Found Exception", "URL not found.")
+	}
+}
+
+func (a *Account) serveSubAccount(w http.ResponseWriter, r *http.Request, path string) {
+	const prefix = "/api/v1/sub-accounts"
+	switch {
+	case r.Method == http.MethodGet && path == prefix:
+		items := nonNil(a.data[SubAccounts])
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		size, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
+		if size <= 0 {
+			size = 20
+		}
+		start := min(page*size, len(items))
+		writeJSON(w, http.StatusOK, map[string]any{
+			"items":      items[start:min(start+size, len(items))],
+			"totalItems": len(items),
+			"totalPages": (len(items) + size - 1) / size,
+			"page":       page,
+		})
+	case r.Method == http.MethodPut && strings.HasPrefix(path, prefix+"/"):
+		id, sub, _ := strings.Cut(strings.TrimPrefix(path, prefix+"/"), "/")
+		targets := a.matching(SubAccounts, []string{id})
+		if len(targets) == 0 {
+			writeGatewayError(w, http.StatusNotFound, "404", "Not Found", "sub account not found")
+			return
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeGatewayError(w, http.StatusBadRequest, "400", "Bad Request", err.Error())
+			return
+		}
+		if sub == "password" {
+			generated := ""
+			if gen, _ := body["needPasswordGenerate"].(bool); gen {
+				generated = "Generated-" + id
+			}
+			writeJSON(w, http.StatusOK, map[string]any{"success": true, "generatedPassword": generated})
+			return
+		}
+		if active, ok := body["active"].(bool); ok {
+			targets[0]["active"] = active
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"success": true})
+	default:
+		writeGatewayError(w, http.StatusNotFound, "300", "Not Found Exception", "URL not found.")
+	}
+}
+
+// matching returns the live items of kind whose id is in ids.
+func (a *Account) matching(kind Kind, ids []string) []map[string]any {
+	field := idField(kind)
+	var out []map[string]any
+	for _, it := range a.data[kind] {
+		for _, id := range ids {
+			if str(it[field]) == id {
+				out = append(out, it)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// remove deletes the item of kind with the given id, reporting whether it existed.
+func (a *Account) remove(kind Kind, id string) bool {
+	field := idField(kind)
+	items := a.data[kind]
+	for i, it := range items {
+		if str(it[field]) == id {
+			a.data[kind] = append(items[:i:i], items[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func routesOf(rt map[string]any) []map[string]any {
+	raw, _ := rt["routeList"].([]any)
+	var routes []map[string]any
+	for _, r := range raw {
+		if m, ok := r.(map[string]any); ok {
+			routes = append(routes, m)
+		}
+	}
+	return routes
+}
+
+// indexed collects "<base>.1", "<base>.2", ... query values.
+func indexed(q map[string][]string, base string) []string {
+	var out []string
+	for i := 1; ; i++ {
+		v := first(q[fmt.Sprintf("%s.%d", base, i)])
+		if v == "" {
+			return out
+		}
+		out = append(out, v)
+	}
+}
+
+func first(v []string) string {
+	if len(v) == 0 {
+		return ""
+	}
+	return v[0]
+}
+
+func str(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return x
+	default:
+		return fmt.Sprint(x)
+	}
+}
+
+func nonNil(items []map[string]any) []map[string]any {
+	if items == nil {
+		return []map[string]any{}
+	}
+	return items
+}
+
+var requestSeq int
+
+func newRequestID() string {
+	requestSeq++
+	return fmt.Sprintf("ncptest-%06d", requestSeq)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeOK(w http.ResponseWriter, action string) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		action + "Response": map[string]any{
+			"requestId": newRequestID(), "returnCode": "0", "returnMessage": "success",
+		},
+	})
+}
+
+// writeActionError mirrors the v2 API error body (HTTP 400 + responseError).
+func writeActionError(w http.ResponseWriter, action, code, msg string) {
+	writeJSON(w, http.StatusBadRequest, map[string]any{
+		"responseError": map[string]any{"returnCode": code, "returnMessage": msg},
+	})
+}
+
+// writeGatewayError mirrors the API gateway error body.
+func writeGatewayError(w http.ResponseWriter, status int, code, msg, details string) {
+	writeJSON(w, status, map[string]any{
+		"error": map[string]any{"errorCode": code, "message": msg, "details": details},
+	})
+}
@@ -0,0 +1,122 @@
+package ncptest
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"ncp-nuke/pkg/ncp"
+)
+
+// client returns a client of the fake with the given keys, without rate
+// limiting or retries.
+func client(srv *Server, accessKey, secretKey string, opts ...ncp.Option) *ncp.Client {
+	opts = append(append(srv.ClientOptions(), ncp.WithRateLimit(0, 0), ncp.WithRetryPolicy(ncp.RetryPolicy{MaxAttempts: 1})), opts...)
+	return ncp.NewClient(accessKey, secretKey, opts...)
+}
+
+func TestAuthentication(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	srv.AddAccount("AK", "SK")
+	ctx := context.Background()
+
+	if _, err := client(srv, "AK", "SK").ListLoginKeys(ctx); err != nil {
+		t.Fatalf("valid keys: %v", err)
+	}
+	for name, keys := range map[string][2]string{
+		"wrong secret": {"AK", "other"},
+		"unknown key":  {"AK2", "SK"},
+	} {
+		_, err := client(srv, keys[0], keys[1]).ListLoginKeys(ctx)
+		if e, ok := ncp.AsAPIError(err); !ok || e.StatusCode != http.StatusUnauthorized {
+			t.Errorf("%s: err = %v, want 401", name, err)
+		}
+	}
+
+	// A request without the gateway headers never reaches the account.
+	resp, err := srv.Client().Get(srv.URL + "/vserver/v2/getLoginKeyList")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("unsigned request: %d, want 401", resp.StatusCode)
+	}
+}
+
+func TestPaging(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	acct := srv.AddAccount("AK", "SK")
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		acct.Seed(LoginKeys, ncp.LoginKey{KeyName: name})
+	}
+
+	keys, err := client(srv, "AK", "SK", ncp.WithPageSize(2)).ListLoginKeys(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, k := range keys {
+		names = append(names, k.KeyName)
+	}
+	if got := strings.Join(names, ","); got != "a,b,c,d,e" {
+		t.Errorf("login keys %s, want a,b,c,d,e", got)
+	}
+	if n := len(srv.Requests()); n != 3 {
+		t.Errorf("%d requests for 5 keys in pages of 2, want 3", n)
+	}
+}
+
+func TestFailNext(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	srv.AddAccount("AK", "SK")
+	c := client(srv, "AK", "SK")
+	ctx := context.Background()
+
+	srv.FailNext(1, http.StatusTooManyRequests)
+	srv.FailNext(1, http.StatusServiceUnavailable)
+	for _, want := range []int{http.StatusTooManyRequests, http.StatusServiceUnavailable} {
+		_, err := c.ListLoginKeys(ctx)
+		if e, ok := ncp.AsAPIError(err); !ok || e.StatusCode != want {
+			t.Errorf("err = %v, want %d", err, want)
+		}
+	}
+	if _, err := c.ListLoginKeys(ctx); err != nil {
+		t.Errorf("after the injected failures: %v", err)
+	}
+
+	// Failed requests are recorded too.
+	reqs := srv.Requests()
+	if len(reqs) != 3 || reqs[0] != "vserver GET /getLoginKeyList" {
+		t.Errorf("requests %q, want 3 login key lists", reqs)
+	}
+}
+
+func TestDeleteDependency(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	acct := srv.AddAccount("AK", "SK")
+	acct.Seed(NatGateways, ncp.NatGatewayInstance{NatGatewayInstanceNo: "400", NatGatewayName: "nat", VpcNo: "v1"})
+	route := ncp.Route{DestinationCidrBlock: "0.0.0.0/0", TargetNo: "400", TargetTypeCode: ncp.CommonCode{Code: "NATGW"}}
+	acct.Seed(RouteTables, ncp.RouteTable{RouteTableNo: "500", RouteTableName: "private", VpcNo: "v1", RouteList: []ncp.Route{route}})
+	c := client(srv, "AK", "SK")
+	ctx := context.Background()
+
+	// Like NCP, a NAT gateway that a route points at cannot be deleted.
+	if err := c.DeleteNatGateway(ctx, "400"); err == nil || acct.Count(NatGateways) != 1 {
+		t.Errorf("routed NAT gateway: err = %v, %d left, want refused", err, acct.Count(NatGateways))
+	}
+	if err := c.RemoveRoute(ctx, "v1", "500", route); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.DeleteNatGateway(ctx, "400"); err != nil || acct.Count(NatGateways) != 0 {
+		t.Errorf("unrouted NAT gateway: err = %v, %d left", err, acct.Count(NatGateways))
+	}
+	if err := c.DeleteNatGateway(ctx, "400"); err == nil {
+		t.Error("deleting a missing NAT gateway: want an error")
+	}
+}
@@ -6,35 +6,62 @@ import (
 	"os"
 	"time"
 
+	"ncp-nuke/pkg/progress"
+
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 )
 
-// NCP Object Storage is S3-compatible. Endpoint/region default to the KR region
-// and can be overridden via environment variables for other regions.
+// NCP Object Storage is S3-compatible. Endpoint/region default to the KR region;
+// the endpoint follows the client's ServiceObjectStorage endpoint and the region
+// can be overridden via NCP_OBJECT_STORAGE_REGION.
 const (
 	defaultObjectStorageEndpoint = "https://kr.object.ncloudstorage.com"
 	defaultObjectStorageRegion   = "kr-standard"
 )
 
+// objectStorageSite is the S3 endpoint and signing region of one NCP region.
+type objectStorageSite struct {
+	endpoint string
+	region   string
+}
+
+// objectStorageSites maps NCP region codes to their Object Storage site.
+var objectStorageSites = map[string]objectStorageSite{
+	"KR":   {"https://kr.object.ncloudstorage.com", "kr-standard"},
+	"USWN": {"https://us.object.ncloudstorage.com", "us-standard"},
+	"SGN":  {"https://sg.object.ncloudstorage.com", "sg-standard"},
+	"JPN":  {"https://jp.object.ncloudstorage.com", "jp-standard"},
+	"DEN":  {"https://de.object.ncloudstorage.com", "de-standard"},
+}
+
 // Bucket represents an Object Storage bucket.
 type Bucket struct {
-	Name string
+	Name    string
+	Created string // RFC 3339
 }
 
-func objectStorageEndpoint() string {
-	if v := os.Getenv("NCP_OBJECT_STORAGE_ENDPOINT"); v != "" {
-		return v
+// objectStorageEndpoint returns the S3 endpoint for the client's region. An
+// explicitly configured endpoint (option, env or config) always wins, and the
+// Financial/Government sites only have the endpoint of their own site.
+func (c *Client) objectStorageEndpoint() string {
+	ep := c.Endpoint(ServiceObjectStorage)
+	if site, ok := objectStorageSites[c.region]; ok && c.site == SitePublic && ep == defaultObjectStorageEndpoint {
+		return site.endpoint
 	}
-	return defaultObjectStorageEndpoint
+	return ep
 }
 
-func objectStorageRegion() string {
+// objectStorageRegion returns the S3 signing region for the client's region.
+func (c *Client) objectStorageRegion() string {
 	if v := os.Getenv("NCP_OBJECT_STORAGE_REGION"); v != "" {
 		return v
 	}
+	if site, ok := objectStorageSites[c.region]; ok {
+		return site.region
+	}
 	return defaultObjectStorageRegion
 }
 
@@ -42,19 +69,20 @@ func objectStorageRegion() string {
 // reusing the account's API access/secret key.
 func (c *Client) newS3Client() *s3.Client {
 	cfg := aws.Config{
-		Region:      objectStorageRegion(),
+		Region:      c.objectStorageRegion(),
 		Credentials: credentials.NewStaticCredentialsProvider(c.accessKey, c.secretKey, ""),
 	}
 	return s3.NewFromConfig(cfg, func(o *s3.Options) {
-		o.BaseEndpoint = aws.String(objectStorageEndpoint())
+		o.BaseEndpoint = aws.String(c.objectStorageEndpoint())
 		o.UsePathStyle = true
+		o.HTTPClient = c.httpClient
 	})
 }
 
 // ListBuckets returns all Object Storage buckets for the account.
-func (c *Client) ListBuckets() ([]Bucket, error) {
+func (c *Client) ListBuckets(ctx context.Context) ([]Bucket, error) {
 	cli := c.newS3Client()
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	out, err := cli.ListBuckets(ctx, &s3.ListBucketsInput{})
@@ -64,24 +92,28 @@ func (c *Client) ListBuckets() ([]Bucket, error) {
 
 	var buckets []Bucket
 	for _, b := range out.Buckets {
-		buckets = append(buckets, Bucket{Name: aws.ToString(b.Name)})
+		bucket := Bucket{Name: aws.ToString(b.Name)}
+		if b.CreationDate != nil {
+			bucket.Created = b.CreationDate.Format(time.RFC3339)
+		}
+		buckets = append(buckets, bucket)
 	}
 	return buckets, nil
 }
 
 // DeleteBucket empties a bucket (all object versions, delete markers and
 // in-progress multipart uploads) and then deletes the bucket itself.
-func (c *Client) DeleteBucket(bucket string, logFn func(string)) error {
+func (c *Client) DeleteBucket(ctx context.Context, bucket string, l progress.Logger) error {
 	cli := c.newS3Client()
 
-	if err := c.abortMultipartUploads(cli, bucket, logFn); err != nil {
-		logFn(fmt.Sprintf("    [경고] 멀티파트 업로드 정리 오류: %v", err))
+	if err := c.abortMultipartUploads(ctx, cli, bucket, l); err != nil {
+		l.Warn(2, "[경고] 멀티파트 업로드 정리 오류: %v", err)
 	}
-	if err := c.deleteAllObjectVersions(cli, bucket, logFn); err != nil {
+	if err := c.deleteAllObjectVersions(ctx, cli, bucket, l); err != nil {
 		return fmt.Errorf("객체 삭제: %w", err)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
 	defer cancel()
 	if _, err := cli.DeleteBucket(ctx, &s3.DeleteBucketInput{Bucket: aws.String(bucket)}); err != nil {
 		return fmt.Errorf("버킷 삭제: %w", err)
@@ -92,14 +124,14 @@ func (c *Client) DeleteBucket(bucket string, logFn func(string)) error {
 // deleteAllObjectVersions removes every object version and delete marker in a
 // bucket. ListObjectVersions covers both versioned and non-versioned buckets
 // (non-versioned objects are returned with a "null" version id).
-func (c *Client) deleteAllObjectVersions(cli *s3.Client, bucket string, logFn func(string)) error {
+func (c *Client) deleteAllObjectVersions(ctx context.Context, cli *s3.Client, bucket string, l progress.Logger) error {
 	paginator := s3.NewListObjectVersionsPaginator(cli, &s3.ListObjectVersionsInput{
 		Bucket: aws.String(bucket),
 	})
 
 	deleted := 0
 	for paginator.HasMorePages() {
-		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
 		page, err := paginator.NextPage(ctx)
 		cancel()
 		if err != nil {
@@ -118,7 +150,7 @@ func (c *Client) deleteAllObjectVersions(cli *s3.Client, bucket string, logFn fu
 		}
 
 		// DeleteObjects accepts up to 1000 keys per call; a single page is <= 1000.
-		dctx, dcancel := context.WithTimeout(context.Background(), 60*time.Second)
+		dctx, dcancel := context.WithTimeout(ctx, 60*time.Second)
 		out, err := cli.DeleteObjects(dctx, &s3.DeleteObjectsInput{
 			Bucket: aws.String(bucket),
 			Delete: &types.Delete{Objects: objs, Quiet: aws.Bool(true)},
@@ -128,29 +160,29 @@ func (c *Client) deleteAllObjectVersions(cli *s3.Client, bucket string, logFn fu
 			return err
 		}
 		for _, e := range out.Errors {
-			logFn(fmt.Sprintf("    [경고] 객체 삭제 실패 %s: %s", aws.ToString(e.Key), aws.ToString(e.Message)))
+			l.Warn(2, "[경고] 객체 삭제 실패 %s: %s", aws.ToString(e.Key), aws.ToString(e.Message))
 		}
 		deleted += len(objs)
-		logFn(fmt.Sprintf("    객체 %d개 삭제됨...", deleted))
+		l.Info(2, "객체 %d개 삭제됨...", deleted)
 	}
 	return nil
 }
 
 // abortMultipartUploads aborts any in-progress multipart uploads so the bucket
 // can be deleted.
-func (c *Client) abortMultipartUploads(cli *s3.Client, bucket string, logFn func(string)) error {
+func (c *Client) abortMultipartUploads(ctx context.Context, cli *s3.Client, bucket string, l progress.Logger) error {
 	paginator := s3.NewListMultipartUploadsPaginator(cli, &s3.ListMultipartUploadsInput{
 		Bucket: aws.String(bucket),
 	})
 	for paginator.HasMorePages() {
-		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+		ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
 		page, err := paginator.NextPage(ctx)
 		cancel()
 		if err != nil {
 			return err
 		}
 		for _, u := range page.Uploads {
-			actx, acancel := context.WithTimeout(context.Background(), 30*time.Second)
+			actx, acancel := context.WithTimeout(ctx, 30*time.Second)
 			_, err := cli.AbortMultipartUpload(actx, &s3.AbortMultipartUploadInput{
 				Bucket:   aws.String(bucket),
 				Key:      u.Key,
@@ -158,7 +190,7 @@ func (c *Client) abortMultipartUploads(cli *s3.Client, bucket string, logFn func
 			})
 			acancel()
 			if err != nil {
-				logFn(fmt.Sprintf("    [경고] 멀티파트 업로드 중단 실패 %s: %v", aws.ToString(u.Key), err))
+				l.Warn(2, "[경고] 멀티파트 업로드 중단 실패 %s: %v", aws.ToString(u.Key), err)
 			}
 		}
 	}
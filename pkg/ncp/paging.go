@@ -0,0 +1,78 @@
+package ncp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// DefaultPageSize is the number of rows requested per page from list APIs.
+const DefaultPageSize = 100
+
+// maxPages guards against an API that keeps returning rows forever.
+const maxPages = 1000
+
+// WithPageSize sets how many rows list APIs request per page.
+func WithPageSize(n int) Option {
+	return func(c *Client) {
+		if n > 0 {
+			c.pageSize = n
+		}
+	}
+}
+
+// listAll walks every page of an NCP v2 list API (pageNo/pageSize) until
+// totalRows rows have been read, decoding the listKey array of each
+// {"<action>Response": {...}} body into T. params may be nil.
+func listAll[T any](ctx context.Context, c *Client, svc Service, action, listKey string, params url.Values) ([]T, error) {
+	q := url.Values{}
+	for k, v := range params {
+		q[k] = v
+	}
+	q.Set("responseFormatType", "json")
+	q.Set("pageSize", strconv.Itoa(c.pageSize))
+
+	var all []T
+	for pageNo := 1; pageNo <= maxPages; pageNo++ {
+		q.Set("pageNo", strconv.Itoa(pageNo))
+		path := "/" + action + "?" + q.Encode()
+		body, status, err := c.doService(ctx, svc, "GET", path, nil)
+		if err != nil {
+			return nil, err
+		}
+		if status != 200 {
+			return nil, newAPIError(svc, "GET", path, status, body)
+		}
+
+		var envelope map[string]json.RawMessage
+		if err := json.Unmarshal(body, &envelope); err != nil {
+			return nil, fmt.Errorf("parsing response: %w", err)
+		}
+		var page struct {
+			TotalRows int `json:"totalRows"`
+		}
+		var rows map[string]json.RawMessage
+		if raw, ok := envelope[action+"Response"]; ok {
+			if err := json.Unmarshal(raw, &page); err != nil {
+				return nil, fmt.Errorf("parsing response: %w", err)
+			}
+			if err := json.Unmarshal(raw, &rows); err != nil {
+				return nil, fmt.Errorf("parsing response: %w", err)
+			}
+		}
+		var items []T
+		if raw, ok := rows[listKey]; ok {
+			if err := json.Unmarshal(raw, &items); err != nil {
+				return nil, fmt.Errorf("parsing response: %w", err)
+			}
+		}
+
+		all = append(all, items...)
+		if len(items) == 0 || len(all) >= page.TotalRows {
+			break
+		}
+	}
+	return all, nil
+}
@@ -0,0 +1,85 @@
+package ncp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Platform selects which NCP platforms are listed and cleaned: the VPC
+// platform, the legacy Classic platform, or both.
+type Platform string
+
+const (
+	// PlatformAuto always covers VPC and adds Classic when the account can
+	// still use it.
+	PlatformAuto    Platform = "auto"
+	PlatformVPC     Platform = "vpc"
+	PlatformClassic Platform = "classic"
+	PlatformAll     Platform = "all"
+)
+
+// ParsePlatform parses a platform name. Empty means auto.
+func ParsePlatform(s string) (Platform, error) {
+	switch p := Platform(strings.ToLower(strings.TrimSpace(s))); p {
+	case "":
+		return PlatformAuto, nil
+	case PlatformAuto, PlatformVPC, PlatformClassic, PlatformAll:
+		return p, nil
+	case "both":
+		return PlatformAll, nil
+	}
+	return "", fmt.Errorf("알 수 없는 플랫폼: %q (auto, vpc, classic, all 중 하나)", s)
+}
+
+// WithPlatform sets which platforms ListAllResources covers.
+func WithPlatform(p Platform) Option {
+	return func(c *Client) {
+		if p != "" {
+			c.platform = p
+		}
+	}
+}
+
+// Platform returns the platform setting of the client.
+func (c *Client) Platform() Platform {
+	return c.platform
+}
+
+// classicUnsupportedCodes are the returnCodes of the Classic APIs for an
+// account that cannot use the platform (created after Classic was closed to
+// new customers).
+var classicUnsupportedCodes = map[string]bool{
+	"800": true,
+}
+
+// SupportsClassic reports whether the account can use the Classic platform,
+// probing the Classic region list. Only the answer of an account without
+// Classic (a 404 or one of classicUnsupportedCodes) means false; any other
+// failure (throttling, server, network or permission errors) is returned, so
+// Classic resources are never skipped silently.
+func (c *Client) SupportsClassic(ctx context.Context) (bool, error) {
+	_, err := listAll[Region](ctx, c, ServiceServer, "getRegionList", "regionList", nil)
+	if err == nil {
+		return true, nil
+	}
+	if e, ok := AsAPIError(err); ok && (e.StatusCode == http.StatusNotFound || classicUnsupportedCodes[e.ReturnCode]) {
+		return false, nil
+	}
+	return false, err
+}
+
+// platforms resolves the client's platform setting into the platforms to list.
+func (c *Client) platforms(ctx context.Context) (vpc, classic bool, err error) {
+	switch c.platform {
+	case PlatformVPC:
+		return true, false, nil
+	case PlatformClassic:
+		return false, true, nil
+	case PlatformAll:
+		return true, true, nil
+	}
+	classic, err = c.SupportsClassic(ctx)
+	return true, classic, err
+}
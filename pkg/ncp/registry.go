@@ -0,0 +1,744 @@
+package ncp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"ncp-nuke/pkg/progress"
+)
+
+// ResourceType describes one kind of NCP resource. The summary accessors,
+// listing, filtering and deletion order are all derived
+// from the registered types (see ResourceTypes), so adding a resource type
+// means adding one entry to the registry.
+//
+// Implementations are provided by this package only.
+type ResourceType interface {
+	// Key is the config key of the type (e.g. "servers").
+	Key() string
+	// Name is the display name used by Breakdown and Items (e.g. "Server").
+	Name() string
+	// Dependents are the keys of the types that must be deleted before this
+	// one (e.g. servers before block storages).
+	Dependents() []string
+	// Tagged reports whether the type has tags; tag filters and the keep
+	// tag never match the resources of the other types.
+	Tagged() bool
+
+	Count(s *ResourceSummary) int
+	Items(s *ResourceSummary) []ResourceItem
+	// Filter keeps only the resources of this type for which keep is true.
+	Filter(s *ResourceSummary, keep func(ResourceItem) bool)
+	// List fills this type's resources of s from the API.
+	List(ctx context.Context, c *Client, s *ResourceSummary) error
+
+	scope() listScope
+	dropImplicit(s *ResourceSummary)
+	cleanup(ctx context.Context, r *cleanupRun, blocked []string) typeResult
+}
+
+// listScope controls when a type is listed.
+type listScope int
+
+const (
+	scopeVPC     listScope = iota // VPC platform only
+	scopeClassic                  // Classic platform only
+	scopeCommon                   // both platforms (Object Storage)
+	scopeGlobal                   // not region-scoped: listed from DefaultRegion only
+)
+
+// resourceType is the ResourceType implementation for resources of type T
+// stored in one ResourceSummary field.
+type resourceType[T any] struct {
+	key, name  string
+	label      string // Korean name used in list errors
+	dependents []string
+	where      listScope
+	verb       string        // deletion verb for the log, default "삭제"
+	timeout    time.Duration // how long to wait for deletions to finish, default 5 minutes
+	// tagged types have tags: servers their own, storages and public IPs
+	// those of the server they are attached to.
+	tagged bool
+
+	field    func(s *ResourceSummary) *[]T
+	identity func(x T) (name, id string)
+	// details returns the status, creation date and parent VPC / subnet of
+	// x (the other fields are ignored).
+	details func(x T) ResourceItem
+	list    func(c *Client, ctx context.Context, s *ResourceSummary) ([]T, error)
+	// skip marks resources that are deleted together with their parent and
+	// are never deleted on their own.
+	skip func(x T) bool
+	// prepare runs once before the resources of the type are deleted (e.g.
+	// stopping servers, removing routes).
+	prepare func(c *Client, ctx context.Context, s *ResourceSummary, items []T, l progress.Logger)
+	// deleteOne deletes one resource; l reports the steps below its
+	// announcement.
+	deleteOne func(c *Client, ctx context.Context, x T, l progress.Logger) error
+}
+
+func (t *resourceType[T]) Key() string          { return t.key }
+func (t *resourceType[T]) Name() string         { return t.name }
+func (t *resourceType[T]) Dependents() []string { return t.dependents }
+func (t *resourceType[T]) Tagged() bool         { return t.tagged }
+func (t *resourceType[T]) scope() listScope     { return t.where }
+
+func (t *resourceType[T]) Count(s *ResourceSummary) int {
+	return len(*t.field(s))
+}
+
+// item describes x, listed in s.
+func (t *resourceType[T]) item(x T, s *ResourceSummary) ResourceItem {
+	var it ResourceItem
+	if t.details != nil {
+		it = t.details(x)
+	}
+	it.Name, it.ID = t.identity(x)
+	it.Region = s.Region
+	it.Tags = s.Tags[it.ID]
+	it.TagsUnknown = s.TagsFailed[it.ID]
+	if it.ServerNo != "" {
+		// Attached to a server: it goes with the server, keep tag included.
+		it.Tags = s.Tags[it.ServerNo]
+		it.TagsUnknown = s.TagsFailed[it.ServerNo]
+	}
+	it.Untagged = !t.tagged
+	if it.VpcNo == "" && it.ServerNo != "" {
+		for _, sv := range s.Servers {
+			if sv.ServerInstanceNo == it.ServerNo {
+				it.VpcNo, it.SubnetNo = sv.VpcNo, sv.SubnetNo
+			}
+		}
+	}
+	if it.VpcNo != "" {
+		var names []string
+		for _, no := range strings.Split(it.VpcNo, ",") {
+			for _, v := range s.Vpcs {
+				if v.VpcNo == no {
+					names = append(names, v.VpcName)
+				}
+			}
+		}
+		it.VpcName = strings.Join(names, ",")
+	}
+	if it.SubnetNo != "" {
+		var names []string
+		for _, no := range strings.Split(it.SubnetNo, ",") {
+			for _, sn := range s.Subnets {
+				if sn.SubnetNo == no {
+					names = append(names, sn.SubnetName)
+				}
+			}
+		}
+		it.SubnetName = strings.Join(names, ",")
+	}
+	return it
+}
+
+func (t *resourceType[T]) Items(s *ResourceSummary) []ResourceItem {
+	var items []ResourceItem
+	for _, x := range *t.field(s) {
+		items = append(items, t.item(x, s))
+	}
+	return items
+}
+
+func (t *resourceType[T]) Filter(s *ResourceSummary, keep func(ResourceItem) bool) {
+	var kept []T
+	for _, x := range *t.field(s) {
+		if keep(t.item(x, s)) {
+			kept = append(kept, x)
+		}
+	}
+	*t.field(s) = kept
+}
+
+// dropImplicit removes the resources matched by skip from s.
+func (t *resourceType[T]) dropImplicit(s *ResourceSummary) {
+	if t.skip == nil {
+		return
+	}
+	var kept []T
+	for _, x := range *t.field(s) {
+		if !t.skip(x) {
+			kept = append(kept, x)
+		}
+	}
+	*t.field(s) = kept
+}
+
+func (t *resourceType[T]) List(ctx context.Context, c *Client, s *ResourceSummary) error {
+	items, err := t.list(c, ctx, s)
+	*t.field(s) = items
+	if err != nil {
+		return fmt.Errorf("%s 조회: %w", t.label, err)
+	}
+	return nil
+}
+
+// joinNonEmpty joins the non-empty values with commas.
+func joinNonEmpty(values ...string) string {
+	var out []string
+	for _, v := range values {
+		if v != "" {
+			out = append(out, v)
+		}
+	}
+	return strings.Join(out, ",")
+}
+
+// listFunc adapts a plain List API to the registry's list signature.
+func listFunc[T any](f func(c *Client, ctx context.Context) ([]T, error)) func(*Client, context.Context, *ResourceSummary) ([]T, error) {
+	return func(c *Client, ctx context.Context, _ *ResourceSummary) ([]T, error) {
+		return f(c, ctx)
+	}
+}
+
+// cloudDBs are the keys of every Cloud DB type.
+var cloudDBs = []string{"cloud_dbs", "cloud_postgresqls", "cloud_mongodbs", "cloud_mariadbs", "cloud_mysqls", "cloud_redises"}
+
+// Deletion timeouts of the slow resource types.
+const (
+	nksDeleteTimeout     = 30 * time.Minute
+	cloudDBDeleteTimeout = 20 * time.Minute
+	asgDeleteTimeout     = 15 * time.Minute
+	lbDeleteTimeout      = 10 * time.Minute
+	nasDeleteTimeout     = 10 * time.Minute
+)
+
+// resourceTypes is the registry, in display order.
+var resourceTypes = []ResourceType{
+	&resourceType[ServerInstance]{
+		key: "servers", name: "Server", label: "서버",
+		tagged:     true,
+		dependents: []string{"nks_clusters", "auto_scaling_groups", "load_balancers", "target_groups"},
+		field:      func(s *ResourceSummary) *[]ServerInstance { return &s.Servers },
+		identity:   func(x ServerInstance) (string, string) { return x.ServerName, x.ServerInstanceNo },
+		details: func(x ServerInstance) ResourceItem {
+			return ResourceItem{Status: x.ServerInstanceStatus.Code, Created: x.CreateDate, VpcNo: x.VpcNo, SubnetNo: x.SubnetNo, Spec: x.spec()}
+		},
+		list:      listFunc((*Client).ListServers),
+		verb:      "반납(삭제)",
+		prepare:   (*Client).prepareServers,
+		deleteOne: (*Client).terminateServer,
+	},
+	&resourceType[BlockStorageInstance]{
+		key: "block_storages", name: "Block Storage", label: "블록 스토리지",
+		tagged:     true,
+		dependents: []string{"servers", "block_storage_snapshots"},
+		field:      func(s *ResourceSummary) *[]BlockStorageInstance { return &s.BlockStorages },
+		identity:   func(x BlockStorageInstance) (string, string) { return x.BlockStorageName, x.BlockStorageInstanceNo },
+		details: func(x BlockStorageInstance) ResourceItem {
+			return ResourceItem{Status: x.BlockStorageInstanceStatus.Code, Created: x.CreateDate, Size: x.BlockStorageSize >> 30, ServerNo: x.ServerInstanceNo}
+		},
+		list: listFunc((*Client).ListBlockStorages),
+		skip: func(x BlockStorageInstance) bool { return x.BlockStorageDiskDetailType.Code == "BASIC" },
+		deleteOne: func(c *Client, ctx context.Context, x BlockStorageInstance, _ progress.Logger) error {
+			return c.DeleteBlockStorages(ctx, []string{x.BlockStorageInstanceNo})
+		},
+	},
+	&resourceType[BlockStorageSnapshotInstance]{
+		key: "block_storage_snapshots", name: "Block Storage Snapshot", label: "블록 스토리지 스냅샷",
+		field: func(s *ResourceSummary) *[]BlockStorageSnapshotInstance { return &s.BlockStorageSnapshots },
+		identity: func(x BlockStorageSnapshotInstance) (string, string) {
+			return x.BlockStorageSnapshotName, x.BlockStorageSnapshotInstanceNo
+		},
+		details: func(x BlockStorageSnapshotInstance) ResourceItem {
+			return ResourceItem{Status: x.BlockStorageSnapshotInstanceStatus.Code, Created: x.CreateDate}
+		},
+		list: listFunc((*Client).ListBlockStorageSnapshotInstances),
+		deleteOne: func(c *Client, ctx context.Context, x BlockStorageSnapshotInstance, _ progress.Logger) error {
+			return c.DeleteBlockStorageSnapshotInstances(ctx, []string{x.BlockStorageSnapshotInstanceNo})
+		},
+	},
+	&resourceType[PublicIpInstance]{
+		key: "public_ips", name: "Public IP", label: "공인 IP",
+		tagged:     true,
+		dependents: []string{"servers", "nat_gateways"},
+		field:      func(s *ResourceSummary) *[]PublicIpInstance { return &s.PublicIps },
+		identity:   func(x PublicIpInstance) (string, string) { return x.PublicIp, x.PublicIpInstanceNo },
+		details: func(x PublicIpInstance) ResourceItem {
+			return ResourceItem{Status: x.PublicIpInstanceStatus.Code, Created: x.CreateDate, ServerNo: x.ServerInstanceNo}
+		},
+		list:      listFunc((*Client).ListPublicIps),
+		verb:      "해제",
+		deleteOne: (*Client).releasePublicIp,
+	},
+	&resourceType[NasVolumeInstance]{
+		key: "nas_volumes", name: "NAS Volume", label: "NAS 볼륨",
+		dependents: []string{"nas_volume_snapshots"},
+		field:      func(s *ResourceSummary) *[]NasVolumeInstance { return &s.NasVolumes },
+		identity:   func(x NasVolumeInstance) (string, string) { return x.VolumeName, x.NasVolumeInstanceNo },
+		details: func(x NasVolumeInstance) ResourceItem {
+			return ResourceItem{Status: x.NasVolumeInstanceStatus.Code, Created: x.CreateDate, Size: x.VolumeTotalSize >> 30}
+		},
+		list:    listFunc((*Client).ListNasVolumes),
+		timeout: nasDeleteTimeout,
+		deleteOne: func(c *Client, ctx context.Context, x NasVolumeInstance, _ progress.Logger) error {
+			return c.DeleteNasVolume(ctx, x.NasVolumeInstanceNo)
+		},
+	},
+	&resourceType[NasVolumeSnapshot]{
+		key: "nas_volume_snapshots", name: "NAS Volume Snapshot", label: "NAS 스냅샷",
+		field: func(s *ResourceSummary) *[]NasVolumeSnapshot { return &s.NasVolumeSnapshots },
+		identity: func(x NasVolumeSnapshot) (string, string) {
+			return x.NasVolumeSnapshotName, x.NasVolumeSnapshotInstanceNo
+		},
+		details: func(x NasVolumeSnapshot) ResourceItem { return ResourceItem{Created: x.CreateDate} },
+		list:    (*Client).listNasVolumeSnapshots,
+		deleteOne: func(c *Client, ctx context.Context, x NasVolumeSnapshot, _ progress.Logger) error {
+			return c.DeleteNasVolumeSnapshot(ctx, x.NasVolumeSnapshotInstanceNo)
+		},
+	},
+	&resourceType[LoadBalancerInstance]{
+		key: "load_balancers", name: "Load Balancer", label: "로드밸런서",
+		field:    func(s *ResourceSummary) *[]LoadBalancerInstance { return &s.LoadBalancers },
+		identity: func(x LoadBalancerInstance) (string, string) { return x.LoadBalancerName, x.LoadBalancerInstanceNo },
+		details: func(x LoadBalancerInstance) ResourceItem {
+			return ResourceItem{Status: x.LoadBalancerInstanceStatus.Code, Created: x.CreateDate, VpcNo: x.VpcNo, SubnetNo: strings.Join(x.SubnetNoList, ",")}
+		},
+		list:    listFunc((*Client).ListLoadBalancers),
+		timeout: lbDeleteTimeout,
+		deleteOne: func(c *Client, ctx context.Context, x LoadBalancerInstance, _ progress.Logger) error {
+			return c.DeleteLoadBalancer(ctx, x.LoadBalancerInstanceNo)
+		},
+	},
+	&resourceType[TargetGroup]{
+		key: "target_groups", name: "Target Group", label: "Target Group",
+		dependents: []string{"load_balancers", "auto_scaling_groups"},
+		field:      func(s *ResourceSummary) *[]TargetGroup { return &s.TargetGroups },
+		identity:   func(x TargetGroup) (string, string) { return x.TargetGroupName, x.TargetGroupNo },
+		details: func(x TargetGroup) ResourceItem {
+			return ResourceItem{Status: x.TargetGroupStatus.Code, Created: x.CreateDate, VpcNo: x.VpcNo}
+		},
+		list: listFunc((*Client).ListTargetGroups),
+		deleteOne: func(c *Client, ctx context.Context, x TargetGroup, _ progress.Logger) error {
+			return c.DeleteTargetGroup(ctx, x.TargetGroupNo)
+		},
+	},
+	&resourceType[CloudDBInstance]{
+		key: "cloud_dbs", name: "Cloud DB", label: "Cloud DB",
+		field:    func(s *ResourceSummary) *[]CloudDBInstance { return &s.CloudDBs },
+		identity: func(x CloudDBInstance) (string, string) { return x.CloudDBServiceName, x.CloudDBInstanceNo },
+		details: func(x CloudDBInstance) ResourceItem {
+			engine := x.EngineVersion
+			if engine == "" {
+				engine = x.DBKindCode
+			}
+			return ResourceItem{Status: x.CloudDBInstanceStatus.Code, Created: x.CreateDate, Engine: engine}
+		},
+		list:    listFunc((*Client).ListCloudDBInstances),
+		timeout: cloudDBDeleteTimeout,
+		verb:    "서비스 해지",
+		deleteOne: func(c *Client, ctx context.Context, x CloudDBInstance, _ progress.Logger) error {
+			return c.DeleteCloudDBInstance(ctx, x.CloudDBInstanceNo)
+		},
+	},
+	&resourceType[CloudPostgresqlInstance]{
+		key: "cloud_postgresqls", name: "Cloud PostgreSQL", label: "Cloud DB(Pg)",
+		field: func(s *ResourceSummary) *[]CloudPostgresqlInstance { return &s.CloudPostgresqls },
+		identity: func(x CloudPostgresqlInstance) (string, string) {
+			return x.CloudPostgresqlServiceName, x.CloudPostgresqlInstanceNo
+		},
+		details: func(x CloudPostgresqlInstance) ResourceItem {
+			return ResourceItem{Status: x.CloudPostgresqlInstanceStatus.Code, Created: x.CreateDate, Engine: x.EngineVersion, VpcNo: x.VpcNo}
+		},
+		list:    listFunc((*Client).ListCloudPostgresqlInstances),
+		timeout: cloudDBDeleteTimeout,
+		verb:    "서비스 해지",
+		deleteOne: func(c *Client, ctx context.Context, x CloudPostgresqlInstance, _ progress.Logger) error {
+			return c.DeleteCloudPostgresqlInstance(ctx, x.CloudPostgresqlInstanceNo)
+		},
+	},
+	&resourceType[CloudMongoDbInstance]{
+		key: "cloud_mongodbs", name: "Cloud MongoDB", label: "Cloud DB(Mongo)",
+		field: func(s *ResourceSummary) *[]CloudMongoDbInstance { return &s.CloudMongoDBs },
+		identity: func(x CloudMongoDbInstance) (string, string) {
+			return x.CloudMongoDbServiceName, x.CloudMongoDbInstanceNo
+		},
+		details: func(x CloudMongoDbInstance) ResourceItem {
+			return ResourceItem{Status: x.CloudMongoDbInstanceStatus.Code, Created: x.CreateDate, Engine: x.EngineVersion, VpcNo: x.VpcNo}
+		},
+		list:    listFunc((*Client).ListCloudMongoDBInstances),
+		timeout: cloudDBDeleteTimeout,
+		verb:    "서비스 해지",
+		deleteOne: func(c *Client, ctx context.Context, x CloudMongoDbInstance, _ progress.Logger) error {
+			return c.DeleteCloudMongoDBInstance(ctx, x.CloudMongoDbInstanceNo)
+		},
+	},
+	&resourceType[CloudMariaDbInstance]{
+		key: "cloud_mariadbs", name: "Cloud MariaDB", label: "Cloud DB(MariaDB)",
+		field: func(s *ResourceSummary) *[]CloudMariaDbInstance { return &s.CloudMariaDBs },
+		identity: func(x CloudMariaDbInstance) (string, string) {
+			return x.CloudMariaDbServiceName, x.CloudMariaDbInstanceNo
+		},
+		details: func(x CloudMariaDbInstance) ResourceItem {
+			return ResourceItem{Status: x.CloudMariaDbInstanceStatus.Code, Created: x.CreateDate, Engine: x.EngineVersion, VpcNo: x.VpcNo}
+		},
+		list:    listFunc((*Client).ListCloudMariaDbInstances),
+		timeout: cloudDBDeleteTimeout,
+		verb:    "서비스 해지",
+		deleteOne: func(c *Client, ctx context.Context, x CloudMariaDbInstance, _ progress.Logger) error {
+			return c.DeleteCloudMariaDbInstance(ctx, x.CloudMariaDbInstanceNo)
+		},
+	},
+	&resourceType[CloudMysqlInstance]{
+		key: "cloud_mysqls", name: "Cloud MySQL", label: "Cloud DB(MySQL)",
+		field:    func(s *ResourceSummary) *[]CloudMysqlInstance { return &s.CloudMySQLs },
+		identity: func(x CloudMysqlInstance) (string, string) { return x.CloudMysqlServiceName, x.CloudMysqlInstanceNo },
+		details: func(x CloudMysqlInstance) ResourceItem {
+			return ResourceItem{Status: x.CloudMysqlInstanceStatus.Code, Created: x.CreateDate, Engine: x.EngineVersion, VpcNo: x.VpcNo}
+		},
+		list:    listFunc((*Client).ListCloudMysqlInstances),
+		timeout: cloudDBDeleteTimeout,
+		verb:    "서비스 해지",
+		deleteOne: func(c *Client, ctx context.Context, x CloudMysqlInstance, _ progress.Logger) error {
+			return c.DeleteCloudMysqlInstance(ctx, x.CloudMysqlInstanceNo)
+		},
+	},
+	&resourceType[CloudRedisInstance]{
+		key: "cloud_redises", name: "Cloud Redis", label: "Cloud DB(Redis)",
+		field:    func(s *ResourceSummary) *[]CloudRedisInstance { return &s.CloudRedises },
+		identity: func(x CloudRedisInstance) (string, string) { return x.CloudRedisServiceName, x.CloudRedisInstanceNo },
+		details: func(x CloudRedisInstance) ResourceItem {
+			return ResourceItem{Status: x.CloudRedisInstanceStatus.Code, Created: x.CreateDate, Engine: x.EngineVersion, VpcNo: x.VpcNo}
+		},
+		list:    listFunc((*Client).ListCloudRedisInstances),
+		timeout: cloudDBDeleteTimeout,
+		verb:    "서비스 해지",
+		deleteOne: func(c *Client, ctx context.Context, x CloudRedisInstance, _ progress.Logger) error {
+			return c.DeleteCloudRedisInstance(ctx, x.CloudRedisInstanceNo)
+		},
+	},
+	&resourceType[Vpc]{
+		key: "vpcs", name: "VPC", label: "VPC",
+		dependents: []string{"subnets", "nat_gateways", "vpc_peerings", "network_acls", "route_tables", "access_control_groups"},
+		field:      func(s *ResourceSummary) *[]Vpc { return &s.Vpcs },
+		identity:   func(x Vpc) (string, string) { return x.VpcName, x.VpcNo },
+		details: func(x Vpc) ResourceItem {
+			return ResourceItem{Status: x.VpcStatus.Code, Created: x.CreateDate, VpcNo: x.VpcNo}
+		},
+		list:      listFunc((*Client).ListVpcs),
+		deleteOne: (*Client).deleteVpc,
+	},
+	&resourceType[Subnet]{
+		key: "subnets", name: "Subnet", label: "Subnet",
+		dependents: append([]string{"servers", "load_balancers", "nat_gateways", "nks_clusters", "auto_scaling_groups"}, cloudDBs...),
+		field:      func(s *ResourceSummary) *[]Subnet { return &s.Subnets },
+		identity:   func(x Subnet) (string, string) { return x.SubnetName, x.SubnetNo },
+		details: func(x Subnet) ResourceItem {
+			return ResourceItem{Status: x.SubnetStatus.Code, Created: x.CreateDate, VpcNo: x.VpcNo, SubnetNo: x.SubnetNo}
+		},
+		list: listFunc((*Client).ListSubnets),
+		deleteOne: func(c *Client, ctx context.Context, x Subnet, _ progress.Logger) error {
+			return c.DeleteSubnet(ctx, x.SubnetNo)
+		},
+	},
+	&resourceType[NatGatewayInstance]{
+		key: "nat_gateways", name: "NAT Gateway", label: "NAT Gateway",
+		field:    func(s *ResourceSummary) *[]NatGatewayInstance { return &s.NatGateways },
+		identity: func(x NatGatewayInstance) (string, string) { return x.NatGatewayName, x.NatGatewayInstanceNo },
+		details: func(x NatGatewayInstance) ResourceItem {
+			return ResourceItem{Status: x.NatGatewayInstanceStatus.Code, Created: x.CreateDate, VpcNo: x.VpcNo, SubnetNo: x.SubnetNo}
+		},
+		list:      listFunc((*Client).ListNatGateways),
+		prepare:   (*Client).removeNatGatewayRoutes,
+		deleteOne: (*Client).deleteNatGateway,
+	},
+	&resourceType[VpcPeeringInstance]{
+		key: "vpc_peerings", name: "VPC Peering", label: "VPC Peering",
+		field:    func(s *ResourceSummary) *[]VpcPeeringInstance { return &s.VpcPeerings },
+		identity: func(x VpcPeeringInstance) (string, string) { return x.VpcPeeringName, x.VpcPeeringInstanceNo },
+		details: func(x VpcPeeringInstance) ResourceItem {
+			return ResourceItem{Status: x.VpcPeeringInstanceStatus.Code, Created: x.CreateDate, VpcNo: joinNonEmpty(x.SourceVpcNo, x.TargetVpcNo)}
+		},
+		list:    listFunc((*Client).ListVpcPeeringInstances),
+		prepare: (*Client).removeVpcPeeringRoutes,
+		deleteOne: func(c *Client, ctx context.Context, x VpcPeeringInstance, _ progress.Logger) error {
+			return c.DeleteVpcPeeringInstance(ctx, x.VpcPeeringInstanceNo)
+		},
+	},
+	&resourceType[NetworkAcl]{
+		key: "network_acls", name: "Network ACL", label: "Network ACL",
+		dependents: []string{"subnets"},
+		field:      func(s *ResourceSummary) *[]NetworkAcl { return &s.NetworkAcls },
+		identity:   func(x NetworkAcl) (string, string) { return x.NetworkAclName, x.NetworkAclNo },
+		details: func(x NetworkAcl) ResourceItem {
+			return ResourceItem{Status: x.NetworkAclStatus.Code, Created: x.CreateDate, VpcNo: x.VpcNo}
+		},
+		list: listFunc((*Client).ListNetworkAcls),
+		skip: func(x NetworkAcl) bool { return x.IsDefault },
+		deleteOne: func(c *Client, ctx context.Context, x NetworkAcl, _ progress.Logger) error {
+			return c.DeleteNetworkAcl(ctx, x.NetworkAclNo)
+		},
+	},
+	&resourceType[RouteTable]{
+		key: "route_tables", name: "Route Table", label: "Route Table",
+		dependents: []string{"subnets"},
+		field:      func(s *ResourceSummary) *[]RouteTable { return &s.RouteTables },
+		identity:   func(x RouteTable) (string, string) { return x.RouteTableName, x.RouteTableNo },
+		details:    func(x RouteTable) ResourceItem { return ResourceItem{Status: x.RouteTableStatus.Code, VpcNo: x.VpcNo} },
+		list:       listFunc((*Client).ListRouteTables),
+		skip:       func(x RouteTable) bool { return x.IsDefault },
+		deleteOne: func(c *Client, ctx context.Context, x RouteTable, _ progress.Logger) error {
+			return c.DeleteRouteTable(ctx, x.VpcNo, x.RouteTableNo)
+		},
+	},
+	&resourceType[AccessControlGroup]{
+		key: "access_control_groups", name: "Access Control Group", label: "ACG",
+		dependents: append([]string{"servers", "load_balancers", "nks_clusters", "auto_scaling_groups"}, cloudDBs...),
+		field:      func(s *ResourceSummary) *[]AccessControlGroup { return &s.AccessControlGroups },
+		identity:   func(x AccessControlGroup) (string, string) { return x.AccessControlGroupName, x.AccessControlGroupNo },
+		details: func(x AccessControlGroup) ResourceItem {
+			return ResourceItem{Status: x.AccessControlGroupStatus.Code, VpcNo: x.VpcNo}
+		},
+		list: listFunc((*Client).ListAccessControlGroups),
+		skip: func(x AccessControlGroup) bool { return x.IsDefault },
+		deleteOne: func(c *Client, ctx context.Context, x AccessControlGroup, _ progress.Logger) error {
+			return c.DeleteAccessControlGroup(ctx, x.VpcNo, x.AccessControlGroupNo)
+		},
+	},
+	&resourceType[AutoScalingGroup]{
+		key: "auto_scaling_groups", name: "Auto Scaling Group", label: "Auto Scaling Group",
+		field:    func(s *ResourceSummary) *[]AutoScalingGroup { return &s.AutoScalingGroups },
+		identity: func(x AutoScalingGroup) (string, string) { return x.AutoScalingGroupName, x.AutoScalingGroupNo },
+		details:  func(x AutoScalingGroup) ResourceItem { return ResourceItem{Created: x.CreateDate, VpcNo: x.VpcNo} },
+		list:     listFunc((*Client).ListAutoScalingGroups),
+		timeout:  asgDeleteTimeout,
+		prepare:  (*Client).drainAutoScalingGroups,
+		deleteOne: func(c *Client, ctx context.Context, x AutoScalingGroup, _ progress.Logger) error {
+			return c.DeleteAutoScalingGroup(ctx, x.AutoScalingGroupNo)
+		},
+	},
+	&resourceType[LaunchConfiguration]{
+		key: "launch_configurations", name: "Launch Configuration", label: "Launch Configuration",
+		dependents: []string{"auto_scaling_groups"},
+		field:      func(s *ResourceSummary) *[]LaunchConfiguration { return &s.LaunchConfigurations },
+		identity: func(x LaunchConfiguration) (string, string) {
+			return x.LaunchConfigurationName, x.LaunchConfigurationNo
+		},
+		details: func(x LaunchConfiguration) ResourceItem { return ResourceItem{Created: x.CreateDate} },
+		list:    listFunc((*Client).ListLaunchConfigurations),
+		deleteOne: func(c *Client, ctx context.Context, x LaunchConfiguration, _ progress.Logger) error {
+			return c.DeleteLaunchConfiguration(ctx, x.LaunchConfigurationNo)
+		},
+	},
+	&resourceType[NksCluster]{
+		key: "nks_clusters", name: "NKS Cluster", label: "NKS Cluster",
+		field:    func(s *ResourceSummary) *[]NksCluster { return &s.NksClusters },
+		identity: func(x NksCluster) (string, string) { return x.Name, x.Uuid },
+		details: func(x NksCluster) ResourceItem {
+			return ResourceItem{Status: x.Status, Created: x.CreatedAt, VpcNo: x.VpcNo.String()}
+		},
+		list:    listFunc((*Client).ListNksClusters),
+		timeout: nksDeleteTimeout,
+		verb:    "서비스 해지",
+		deleteOne: func(c *Client, ctx context.Context, x NksCluster, _ progress.Logger) error {
+			return c.DeleteNksCluster(ctx, x.Uuid)
+		},
+	},
+	&resourceType[InitScript]{
+		key: "init_scripts", name: "Init Script", label: "Init Script",
+		dependents: []string{"servers", "launch_configurations", "classic_servers"},
+		field:      func(s *ResourceSummary) *[]InitScript { return &s.InitScripts },
+		identity:   func(x InitScript) (string, string) { return x.InitScriptName, x.InitScriptNo },
+		details:    func(x InitScript) ResourceItem { return ResourceItem{Created: x.CreateDate} },
+		list:       listFunc((*Client).ListInitScripts),
+		deleteOne: func(c *Client, ctx context.Context, x InitScript, _ progress.Logger) error {
+			return c.DeleteInitScripts(ctx, []string{x.InitScriptNo})
+		},
+	},
+	&resourceType[LoginKey]{
+		key: "login_keys", name: "Login Key", label: "Login Key",
+		dependents: []string{"servers", "launch_configurations", "nks_clusters", "classic_servers"},
+		field:      func(s *ResourceSummary) *[]LoginKey { return &s.LoginKeys },
+		identity:   func(x LoginKey) (string, string) { return x.KeyName, "" },
+		details:    func(x LoginKey) ResourceItem { return ResourceItem{Created: x.CreateDate} },
+		list:       listFunc((*Client).ListLoginKeys),
+		deleteOne: func(c *Client, ctx context.Context, x LoginKey, _ progress.Logger) error {
+			return c.DeleteLoginKey(ctx, x.KeyName)
+		},
+	},
+	&resourceType[PlacementGroup]{
+		key: "placement_groups", name: "Placement Group", label: "Placement Group",
+		dependents: []string{"servers"},
+		field:      func(s *ResourceSummary) *[]PlacementGroup { return &s.PlacementGroups },
+		identity:   func(x PlacementGroup) (string, string) { return x.PlacementGroupName, x.PlacementGroupNo },
+		list:       listFunc((*Client).ListPlacementGroups),
+		deleteOne: func(c *Client, ctx context.Context, x PlacementGroup, _ progress.Logger) error {
+			return c.DeletePlacementGroup(ctx, x.PlacementGroupNo)
+		},
+	},
+	&resourceType[Bucket]{
+		key: "buckets", name: "Object Storage Bucket", label: "Object Storage",
+		where:    scopeCommon,
+		field:    func(s *ResourceSummary) *[]Bucket { return &s.Buckets },
+		identity: func(x Bucket) (string, string) { return x.Name, "" },
+		details:  func(x Bucket) ResourceItem { return ResourceItem{Created: x.Created} },
+		list:     listFunc((*Client).ListBuckets),
+		verb:     "버킷 비우기/삭제",
+		deleteOne: func(c *Client, ctx context.Context, x Bucket, l progress.Logger) error {
+			return c.DeleteBucket(ctx, x.Name, l)
+		},
+	},
+	&resourceType[ApiGatewayProduct]{
+		key: "api_gateway_products", name: "API Gateway Product", label: "API Gateway",
+		where:    scopeGlobal,
+		field:    func(s *ResourceSummary) *[]ApiGatewayProduct { return &s.ApiGatewayProducts },
+		identity: func(x ApiGatewayProduct) (string, string) { return x.ProductName, x.ProductId },
+		list:     listFunc((*Client).ListApiGatewayProducts),
+		deleteOne: func(c *Client, ctx context.Context, x ApiGatewayProduct, _ progress.Logger) error {
+			return c.DeleteApiGatewayProduct(ctx, x.ProductId)
+		},
+	},
+	&resourceType[ServerInstance]{
+		key: "classic_servers", name: "Classic Server", label: "Classic 서버",
+		tagged:     true,
+		where:      scopeClassic,
+		dependents: []string{"classic_load_balancers", "classic_public_ips"},
+		field:      func(s *ResourceSummary) *[]ServerInstance { return &s.ClassicServers },
+		identity:   func(x ServerInstance) (string, string) { return x.ServerName, x.ServerInstanceNo },
+		details: func(x ServerInstance) ResourceItem {
+			return ResourceItem{Status: x.ServerInstanceStatus.Code, Created: x.CreateDate, VpcNo: x.VpcNo, SubnetNo: x.SubnetNo, Spec: x.spec()}
+		},
+		list:    listFunc((*Client).ListClassicServers),
+		verb:    "반납(삭제)",
+		prepare: (*Client).prepareClassicServers,
+		deleteOne: func(c *Client, ctx context.Context, x ServerInstance, _ progress.Logger) error {
+			return c.TerminateClassicServers(ctx, []string{x.ServerInstanceNo})
+		},
+	},
+	&resourceType[BlockStorageInstance]{
+		key: "classic_block_storages", name: "Classic Block Storage", label: "Classic 블록 스토리지",
+		tagged:     true,
+		where:      scopeClassic,
+		dependents: []string{"classic_servers"},
+		field:      func(s *ResourceSummary) *[]BlockStorageInstance { return &s.ClassicBlockStorages },
+		identity:   func(x BlockStorageInstance) (string, string) { return x.BlockStorageName, x.BlockStorageInstanceNo },
+		details: func(x BlockStorageInstance) ResourceItem {
+			return ResourceItem{Status: x.BlockStorageInstanceStatus.Code, Created: x.CreateDate, Size: x.BlockStorageSize >> 30, ServerNo: x.ServerInstanceNo}
+		},
+		list: listFunc((*Client).ListClassicBlockStorages),
+		skip: func(x BlockStorageInstance) bool { return x.BlockStorageType.Code == "BASIC" },
+		deleteOne: func(c *Client, ctx context.Context, x BlockStorageInstance, _ progress.Logger) error {
+			return c.DeleteClassicBlockStorages(ctx, []string{x.BlockStorageInstanceNo})
+		},
+	},
+	&resourceType[ClassicPublicIpInstance]{
+		key: "classic_public_ips", name: "Classic Public IP", label: "Classic 공인 IP",
+		tagged:   true,
+		where:    scopeClassic,
+		field:    func(s *ResourceSummary) *[]ClassicPublicIpInstance { return &s.ClassicPublicIps },
+		identity: func(x ClassicPublicIpInstance) (string, string) { return x.PublicIp, x.PublicIpInstanceNo },
+		details: func(x ClassicPublicIpInstance) ResourceItem {
+			it := ResourceItem{Status: x.PublicIpInstanceStatus.Code, Created: x.CreateDate}
+			if x.ServerInstance != nil {
+				it.ServerNo = x.ServerInstance.ServerInstanceNo
+			}
+			return it
+		},
+		list:      listFunc((*Client).ListClassicPublicIps),
+		verb:      "해제",
+		deleteOne: (*Client).releaseClassicPublicIp,
+	},
+	&resourceType[NasVolumeInstance]{
+		key: "classic_nas_volumes", name: "Classic NAS Volume", label: "Classic NAS 볼륨",
+		where:      scopeClassic,
+		dependents: []string{"classic_servers"},
+		field:      func(s *ResourceSummary) *[]NasVolumeInstance { return &s.ClassicNasVolumes },
+		identity:   func(x NasVolumeInstance) (string, string) { return x.VolumeName, x.NasVolumeInstanceNo },
+		details: func(x NasVolumeInstance) ResourceItem {
+			return ResourceItem{Status: x.NasVolumeInstanceStatus.Code, Created: x.CreateDate, Size: x.VolumeTotalSize >> 30}
+		},
+		list:    listFunc((*Client).ListClassicNasVolumes),
+		timeout: nasDeleteTimeout,
+		deleteOne: func(c *Client, ctx context.Context, x NasVolumeInstance, _ progress.Logger) error {
+			return c.DeleteClassicNasVolume(ctx, x.NasVolumeInstanceNo)
+		},
+	},
+	&resourceType[LoadBalancerInstance]{
+		key: "classic_load_balancers", name: "Classic Load Balancer", label: "Classic 로드밸런서",
+		where:    scopeClassic,
+		field:    func(s *ResourceSummary) *[]LoadBalancerInstance { return &s.ClassicLoadBalancers },
+		identity: func(x LoadBalancerInstance) (string, string) { return x.LoadBalancerName, x.LoadBalancerInstanceNo },
+		details: func(x LoadBalancerInstance) ResourceItem {
+			return ResourceItem{Status: x.LoadBalancerInstanceStatus.Code, Created: x.CreateDate, VpcNo: x.VpcNo, SubnetNo: strings.Join(x.SubnetNoList, ",")}
+		},
+		list:    listFunc((*Client).ListClassicLoadBalancers),
+		timeout: lbDeleteTimeout,
+		deleteOne: func(c *Client, ctx context.Context, x LoadBalancerInstance, _ progress.Logger) error {
+			return c.DeleteClassicLoadBalancer(ctx, x.LoadBalancerInstanceNo)
+		},
+	},
+}
+
+// deletionOrder is the registry sorted so that every type comes after its
+// dependents; ties keep registry order.
+var deletionOrder = sortByDependents(resourceTypes)
+
+// ResourceTypes returns every registered resource type in display order.
+func ResourceTypes() []ResourceType {
+	return resourceTypes
+}
+
+// DeletionOrder returns every registered resource type in deletion order:
+// each type comes after the types that must be deleted before it.
+func DeletionOrder() []ResourceType {
+	return deletionOrder
+}
+
+// LookupResourceType returns the type with the given config key or display
+// name.
+func LookupResourceType(keyOrName string) (ResourceType, bool) {
+	for _, rt := range resourceTypes {
+		if rt.Key() == keyOrName || rt.Name() == keyOrName {
+			return rt, true
+		}
+	}
+	return nil, false
+}
+
+// sortByDependents orders types so that each comes after all of its
+// dependents. It panics on an unknown key or a cycle, which are registry bugs.
+func sortByDependents(types []ResourceType) []ResourceType {
+	index := make(map[string]int, len(types))
+	for i, rt := range types {
+		index[rt.Key()] = i
+	}
+	pending := make([]int, len(types)) // dependents not yet placed
+	for i, rt := range types {
+		for _, dep := range rt.Dependents() {
+			if _, ok := index[dep]; !ok {
+				panic(fmt.Sprintf("ncp: %s depends on unknown resource type %q", rt.Key(), dep))
+			}
+			pending[i]++
+		}
+	}
+
+	placed := make([]bool, len(types))
+	out := make([]ResourceType, 0, len(types))
+	for len(out) < len(types) {
+		next := -1
+		for i := range types {
+			if !placed[i] && pending[i] == 0 {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			panic("ncp: resource type dependencies contain a cycle")
+		}
+		placed[next] = true
+		out = append(out, types[next])
+		for i, rt := range types {
+			for _, dep := range rt.Dependents() {
+				if dep == types[next].Key() {
+					pending[i]--
+				}
+			}
+		}
+	}
+	return out
+}
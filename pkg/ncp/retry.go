@@ -0,0 +1,189 @@
+package ncp
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy controls how API calls are retried on throttling (429),
+// transient server errors (5xx) and network errors.
+type RetryPolicy struct {
+	MaxAttempts int           // total attempts including the first; <= 1 disables retries
+	BaseDelay   time.Duration // backoff before the 2nd attempt; doubles each retry
+	MaxDelay    time.Duration // cap for a single backoff (and for Retry-After)
+}
+
+// DefaultRetryPolicy is used unless overridden with WithRetryPolicy.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 5,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    30 * time.Second,
+}
+
+// Default client-side rate limit per access key (requests/second, burst).
+const (
+	DefaultRateLimit = 10
+	DefaultRateBurst = 10
+)
+
+// WithRetryPolicy replaces the retry policy.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = p
+	}
+}
+
+// WithRateLimit sets the client-side request rate (requests/second) and burst.
+// A rate <= 0 disables limiting. The limit is per access key: every client of
+// the key shares it, with the rate and burst of the latest one.
+func WithRateLimit(perSecond float64, burst int) Option {
+	return func(c *Client) {
+		c.rateLimit, c.rateBurst = perSecond, burst
+	}
+}
+
+// shouldRetry reports whether a response status is worth retrying.
+func shouldRetry(status int) bool {
+	return status == http.StatusTooManyRequests || status >= 500
+}
+
+// retryableError reports whether an error of a request is a transport
+// failure worth retrying: a network error, a reset or closed connection or a
+// response cut short. Errors building the request (a bad URL, ...) fail the
+// same way every time.
+func retryableError(err error) bool {
+	var ue *url.Error
+	if errors.As(err, &ue) {
+		// Both http.Client.Do and url.Parse wrap their errors in a
+		// url.Error, itself a net.Error: judge by the cause.
+		err = ue.Err
+	}
+	var ne net.Error
+	return errors.As(err, &ne) || errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// backoff returns the delay before retry number attempt (1-based): exponential
+// with full jitter, or the server's Retry-After when it sent one.
+func (p RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if d, ok := retryAfter(resp); ok {
+		return min(d, p.MaxDelay)
+	}
+	d := p.BaseDelay << (attempt - 1)
+	if d <= 0 || d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	return time.Duration(rand.Int64N(int64(d) + 1))
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(time.Until(t), 0), true
+	}
+	return 0, false
+}
+
+// tokenBucket is a simple token-bucket rate limiter. A nil bucket never blocks.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(perSecond float64, burst int) *tokenBucket {
+	if perSecond <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{rate: perSecond, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+// buckets holds the rate limiter of every access key (per site) a client was
+// made for, so that clients of the same key share one limit.
+var buckets = struct {
+	sync.Mutex
+	m map[string]*tokenBucket
+}{m: map[string]*tokenBucket{}}
+
+// sharedBucket returns the limiter of accessKey on site, retuned to perSecond
+// and burst; nil when perSecond <= 0.
+func sharedBucket(accessKey string, site Site, perSecond float64, burst int) *tokenBucket {
+	if perSecond <= 0 {
+		return nil
+	}
+	key := string(site) + "\x00" + accessKey
+	buckets.Lock()
+	defer buckets.Unlock()
+	b := buckets.m[key]
+	if b == nil {
+		b = newTokenBucket(perSecond, burst)
+		buckets.m[key] = b
+		return b
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.rate, b.burst = perSecond, float64(max(burst, 1))
+	b.tokens = min(b.tokens, b.burst)
+	return b
+}
+
+// reserve takes a token and returns how long the caller must wait before using it.
+func (b *tokenBucket) reserve() time.Duration {
+	if b == nil {
+		return 0
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// wait blocks until a token is available or ctx is done.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	return sleepCtx(ctx, b.reserve())
+}
+
+// sleepCtx waits d, returning ctx.Err() early if ctx is done first.
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,72 @@
+package ncp
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Site identifies an NCP cloud site. Financial and Government clouds expose the
+// same APIs under their own domains (apigw.fin-ntruss.com, apigw.gov-ntruss.com).
+type Site string
+
+const (
+	SitePublic Site = "public"
+	SiteFin    Site = "fin"
+	SiteGov    Site = "gov"
+)
+
+// siteDomains maps a site to its API Gateway and Object Storage domains.
+var siteDomains = map[Site]struct{ apigw, storage string }{
+	SitePublic: {"apigw.ntruss.com", "object.ncloudstorage.com"},
+	SiteFin:    {"apigw.fin-ntruss.com", "object.fin-ncloudstorage.com"},
+	SiteGov:    {"apigw.gov-ntruss.com", "object.gov-ncloudstorage.com"},
+}
+
+// ParseSite parses a site name. Empty means public; "financial"/"금융" and
+// "government"/"공공" are accepted as aliases.
+func ParseSite(s string) (Site, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "public", "pub", "민간":
+		return SitePublic, nil
+	case "fin", "financial", "금융":
+		return SiteFin, nil
+	case "gov", "government", "공공":
+		return SiteGov, nil
+	}
+	return "", fmt.Errorf("알 수 없는 사이트: %q (public, fin, gov 중 하나)", s)
+}
+
+// Endpoints returns the base URL of every service on the site.
+func (s Site) Endpoints() map[Service]string {
+	pub, dom := siteDomains[SitePublic], siteDomains[s]
+	m := make(map[Service]string, len(DefaultEndpoints))
+	for svc, u := range DefaultEndpoints {
+		if dom.apigw != "" {
+			u = strings.Replace(u, pub.apigw, dom.apigw, 1)
+			u = strings.Replace(u, pub.storage, dom.storage, 1)
+		}
+		m[svc] = u
+	}
+	return m
+}
+
+// WithSite switches the client to the endpoint set of a site. Endpoints that
+// were already overridden (environment or earlier options) are kept.
+func WithSite(site Site) Option {
+	return func(c *Client) {
+		if site == "" {
+			site = SitePublic
+		}
+		c.site = site
+		for svc, u := range site.Endpoints() {
+			if c.endpoints[svc] == DefaultEndpoints[svc] {
+				c.endpoints[svc] = u
+			}
+		}
+	}
+}
+
+// Site returns the cloud site the client talks to.
+func (c *Client) Site() Site {
+	return c.site
+}
@@ -2,24 +2,25 @@ package ncp
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 )
 
 // ListSubAccounts retrieves all sub accounts for the authenticated root account.
-func (c *Client) ListSubAccounts() ([]SubAccount, error) {
+func (c *Client) ListSubAccounts(ctx context.Context) ([]SubAccount, error) {
 	var all []SubAccount
 	page := 0
-	pageSize := 100
+	pageSize := c.pageSize
 
 	for {
 		path := fmt.Sprintf("/api/v1/sub-accounts?pageSize=%d&page=%d", pageSize, page)
-		body, statusCode, err := c.doRequest("GET", path, nil)
+		body, statusCode, err := c.doRequest(ctx, "GET", path, nil)
 		if err != nil {
 			return nil, fmt.Errorf("listing sub accounts: %w", err)
 		}
 		if statusCode != 200 {
-			return nil, fmt.Errorf("listing sub accounts: HTTP %d - %s", statusCode, string(body))
+			return nil, fmt.Errorf("listing sub accounts: %w", newAPIError(ServiceSubAccount, "GET", path, statusCode, body))
 		}
 
 		var resp SubAccountListResponse
@@ -39,19 +40,19 @@ func (c *Client) ListSubAccounts() ([]SubAccount, error) {
 }
 
 // UpdateSubAccount updates a sub account by ID.
-func (c *Client) UpdateSubAccount(subAccountId string, req *SubAccountUpdateRequest) error {
+func (c *Client) UpdateSubAccount(ctx context.Context, subAccountId string, req *SubAccountUpdateRequest) error {
 	payload, err := json.Marshal(req)
 	if err != nil {
 		return fmt.Errorf("marshaling request: %w", err)
 	}
 
 	path := fmt.Sprintf("/api/v1/sub-accounts/%s", subAccountId)
-	body, statusCode, err := c.doRequest("PUT", path, bytes.NewReader(payload))
+	body, statusCode, err := c.doRequest(ctx, "PUT", path, bytes.NewReader(payload))
 	if err != nil {
 		return fmt.Errorf("updating sub account: %w", err)
 	}
 	if statusCode != 200 {
-		return fmt.Errorf("updating sub account: HTTP %d - %s", statusCode, string(body))
+		return fmt.Errorf("updating sub account: %w", newAPIError(ServiceSubAccount, "PUT", path, statusCode, body))
 	}
 
 	var resp SubAccountUpdateResponse
@@ -68,7 +69,7 @@ func (c *Client) UpdateSubAccount(subAccountId string, req *SubAccountUpdateRequ
 // ResetPassword resets a sub account's login password.
 // If password is empty, it auto-generates a password and returns it.
 // If password is provided, it sets the password to the given value.
-func (c *Client) ResetPassword(subAccountId string, password string) (string, error) {
+func (c *Client) ResetPassword(ctx context.Context, subAccountId string, password string) (string, error) {
 	var req PasswordResetRequest
 	if password == "" {
 		req.NeedPasswordGenerate = true
@@ -83,12 +84,12 @@ func (c *Client) ResetPassword(subAccountId string, password string) (string, er
 	}
 
 	path := fmt.Sprintf("/api/v1/sub-accounts/%s/password", subAccountId)
-	body, statusCode, err := c.doRequest("PUT", path, bytes.NewReader(payload))
+	body, statusCode, err := c.doRequest(ctx, "PUT", path, bytes.NewReader(payload))
 	if err != nil {
 		return "", fmt.Errorf("resetting password: %w", err)
 	}
 	if statusCode != 200 {
-		return "", fmt.Errorf("resetting password: HTTP %d - %s", statusCode, string(body))
+		return "", fmt.Errorf("resetting password: %w", newAPIError(ServiceSubAccount, "PUT", path, statusCode, body))
 	}
 
 	var resp PasswordResetResponse
@@ -104,28 +105,28 @@ func (c *Client) ResetPassword(subAccountId string, password string) (string, er
 
 // ActivateSubAccount activates a sub account and resets its password.
 // Returns the generated password (if auto-generated) and any error.
-func (c *Client) ActivateSubAccount(sa SubAccount, password string) (string, error) {
+func (c *Client) ActivateSubAccount(ctx context.Context, sa SubAccount, password string) (string, error) {
 	// 1. 활성화
 	active := true
 	req := &SubAccountUpdateRequest{
 		Name:   &sa.Name,
 		Active: &active,
 	}
-	if err := c.UpdateSubAccount(sa.SubAccountId, req); err != nil {
+	if err := c.UpdateSubAccount(ctx, sa.SubAccountId, req); err != nil {
 		return "", err
 	}
 
 	// 2. 비밀번호 재설정 (별도 API)
-	generatedPw, err := c.ResetPassword(sa.SubAccountId, password)
+	generatedPw, err := c.ResetPassword(ctx, sa.SubAccountId, password)
 	return generatedPw, err
 }
 
 // DeactivateSubAccount deactivates (suspends) a sub account.
-func (c *Client) DeactivateSubAccount(sa SubAccount) error {
+func (c *Client) DeactivateSubAccount(ctx context.Context, sa SubAccount) error {
 	active := false
 	req := &SubAccountUpdateRequest{
 		Name:   &sa.Name,
 		Active: &active,
 	}
-	return c.UpdateSubAccount(sa.SubAccountId, req)
+	return c.UpdateSubAccount(ctx, sa.SubAccountId, req)
 }
@@ -1,5 +1,10 @@
 package ncp
 
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
 // RootAccount represents a root account parsed from the Excel file.
 type RootAccount struct {
 	AccountName string
@@ -7,6 +12,16 @@ type RootAccount struct {
 	SecretKey   string
 	IamUsername string
 	Password    string
+	Site        Site     // "" = the configured default site (public)
+	Platform    Platform // "" = the configured default platform (auto)
+	Group       string   // optional label to select accounts by (e.g. "lab")
+	MemberNo    string   // NCP member number resolved from the keys (Client.MemberNo); "" = unknown
+}
+
+// Fingerprint identifies the account's access key pair without revealing it.
+func (a RootAccount) Fingerprint() string {
+	sum := sha256.Sum256([]byte(a.AccessKey + "\x00" + a.SecretKey))
+	return hex.EncodeToString(sum[:8])
 }
 
 // SubAccount represents a sub account returned from the NCP API.
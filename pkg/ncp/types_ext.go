@@ -17,6 +17,13 @@ type apiResponse struct {
 	Content       json.RawMessage `json:"-"`
 }
 
+// --- Region ---
+type Region struct {
+	RegionNo   string `json:"regionNo"`
+	RegionCode string `json:"regionCode"`
+	RegionName string `json:"regionName"`
+}
+
 // --- Server ---
 type ServerInstance struct {
 	ServerInstanceNo     string     `json:"serverInstanceNo"`
@@ -26,6 +33,19 @@ type ServerInstance struct {
 	PrivateIp            string     `json:"privateIp"`
 	CpuCount             int        `json:"cpuCount"`
 	MemorySize           int64      `json:"memorySize"`
+	VpcNo                string     `json:"vpcNo"`
+	SubnetNo             string     `json:"subnetNo"`
+	CreateDate           string     `json:"createDate"`
+	ServerSpecCode       string     `json:"serverSpecCode"`    // VPC
+	ServerProductCode    string     `json:"serverProductCode"` // Classic
+}
+
+// spec is the server's spec code (VPC) or product code (Classic).
+func (x ServerInstance) spec() string {
+	if x.ServerSpecCode != "" {
+		return x.ServerSpecCode
+	}
+	return x.ServerProductCode
 }
 
 // --- Block Storage ---
@@ -37,6 +57,7 @@ type BlockStorageInstance struct {
 	ServerInstanceNo           string     `json:"serverInstanceNo"`
 	BlockStorageType           CommonCode `json:"blockStorageType"`
 	BlockStorageDiskDetailType CommonCode `json:"blockStorageDiskDetailType"`
+	CreateDate                 string     `json:"createDate"`
 }
 
 // --- Public IP ---
@@ -46,6 +67,7 @@ type PublicIpInstance struct {
 	PublicIpInstanceStatus CommonCode `json:"publicIpInstanceStatus"`
 	ServerInstanceNo       string     `json:"serverInstanceNo"`
 	ServerName             string     `json:"serverName"`
+	CreateDate             string     `json:"createDate"`
 }
 
 // --- NAS Volume ---
@@ -55,6 +77,18 @@ type NasVolumeInstance struct {
 	NasVolumeInstanceStatus CommonCode `json:"nasVolumeInstanceStatus"`
 	VolumeAllotmentProtocol CommonCode `json:"volumeAllotmentProtocolType"`
 	VolumeTotalSize         int64      `json:"volumeTotalSize"`
+	CreateDate              string     `json:"createDate"`
+}
+
+// --- Classic Public IP ---
+// Classic servers, block storage, NAS volumes and load balancers share the VPC
+// types; only the public IP nests its server.
+type ClassicPublicIpInstance struct {
+	PublicIpInstanceNo     string          `json:"publicIpInstanceNo"`
+	PublicIp               string          `json:"publicIp"`
+	PublicIpInstanceStatus CommonCode      `json:"publicIpInstanceStatus"`
+	ServerInstance         *ServerInstance `json:"serverInstance,omitempty"`
+	CreateDate             string          `json:"createDate"`
 }
 
 // --- Load Balancer ---
@@ -63,6 +97,9 @@ type LoadBalancerInstance struct {
 	LoadBalancerName           string     `json:"loadBalancerName"`
 	LoadBalancerInstanceStatus CommonCode `json:"loadBalancerInstanceStatus"`
 	LoadBalancerType           CommonCode `json:"loadBalancerType"`
+	VpcNo                      string     `json:"vpcNo"`
+	SubnetNoList               []string   `json:"subnetNoList"`
+	CreateDate                 string     `json:"createDate"`
 }
 
 // --- Cloud DB ---
@@ -71,6 +108,8 @@ type CloudDBInstance struct {
 	CloudDBServiceName    string     `json:"cloudDBServiceName"`
 	CloudDBInstanceStatus CommonCode `json:"cloudDBInstanceStatus"`
 	DBKindCode            string     `json:"dbKindCode"` // MYSQL, MSSQL, REDIS
+	CreateDate            string     `json:"createDate"`
+	EngineVersion         string     `json:"engineVersion"`
 }
 
 // --- Cloud DB for PostgreSQL ---
@@ -78,6 +117,9 @@ type CloudPostgresqlInstance struct {
 	CloudPostgresqlInstanceNo     string     `json:"cloudPostgresqlInstanceNo"`
 	CloudPostgresqlServiceName    string     `json:"cloudPostgresqlServiceName"`
 	CloudPostgresqlInstanceStatus CommonCode `json:"cloudPostgresqlInstanceStatus"`
+	CreateDate                    string     `json:"createDate"`
+	EngineVersion                 string     `json:"engineVersion"`
+	VpcNo                         string     `json:"vpcNo"`
 }
 
 // --- Cloud DB for MongoDB ---
@@ -85,14 +127,18 @@ type CloudMongoDbInstance struct {
 	CloudMongoDbInstanceNo     string     `json:"cloudMongoDbInstanceNo"`
 	CloudMongoDbServiceName    string     `json:"cloudMongoDbServiceName"`
 	CloudMongoDbInstanceStatus CommonCode `json:"cloudMongoDbInstanceStatus"`
+	CreateDate                 string     `json:"createDate"`
+	EngineVersion              string     `json:"engineVersion"`
+	VpcNo                      string     `json:"vpcNo"`
 }
 
 // --- VPC ---
 type Vpc struct {
-	VpcNo     string     `json:"vpcNo"`
-	VpcName   string     `json:"vpcName"`
-	VpcStatus CommonCode `json:"vpcStatus"`
-	Ipv4Cidr  string     `json:"ipv4CidrBlock"`
+	VpcNo      string     `json:"vpcNo"`
+	VpcName    string     `json:"vpcName"`
+	VpcStatus  CommonCode `json:"vpcStatus"`
+	Ipv4Cidr   string     `json:"ipv4CidrBlock"`
+	CreateDate string     `json:"createDate"`
 }
 
 type Subnet struct {
@@ -100,6 +146,7 @@ type Subnet struct {
 	SubnetName   string     `json:"subnetName"`
 	SubnetStatus CommonCode `json:"subnetStatus"`
 	VpcNo        string     `json:"vpcNo"`
+	CreateDate   string     `json:"createDate"`
 }
 
 type NatGatewayInstance struct {
@@ -107,6 +154,8 @@ type NatGatewayInstance struct {
 	NatGatewayName           string     `json:"natGatewayName"`
 	NatGatewayInstanceStatus CommonCode `json:"natGatewayInstanceStatus"`
 	VpcNo                    string     `json:"vpcNo"`
+	SubnetNo                 string     `json:"subnetNo"`
+	CreateDate               string     `json:"createDate"`
 }
 
 // --- Route Table ---
@@ -141,13 +190,23 @@ type AutoScalingGroup struct {
 	AutoScalingGroupNo   string     `json:"autoScalingGroupNo"`
 	InAutoScalingGroupNo string     `json:"inAutoScalingGroupNo"` // Sometimes used
 	HealthCheckTypeCode  CommonCode `json:"healthCheckTypeCode"`
+	DesiredCapacity      int        `json:"desiredCapacity"`
+	// InAutoScalingGroupServerInstanceList holds the servers the group
+	// still manages.
+	InAutoScalingGroupServerInstanceList []struct {
+		ServerInstanceNo string `json:"serverInstanceNo"`
+	} `json:"inAutoScalingGroupServerInstanceList"`
+	VpcNo      string `json:"vpcNo"`
+	CreateDate string `json:"createDate"`
 }
 
 // --- NKS (Kubernetes) ---
 type NksCluster struct {
-	Uuid   string `json:"uuid"`
-	Name   string `json:"name"`
-	Status string `json:"status"`
+	Uuid      string      `json:"uuid"`
+	Name      string      `json:"name"`
+	Status    string      `json:"status"`
+	CreatedAt string      `json:"createdAt"`
+	VpcNo     json.Number `json:"vpcNo,omitempty"` // a number in the NKS API
 }
 
 // --- Cloud DB for MariaDB ---
@@ -155,6 +214,9 @@ type CloudMariaDbInstance struct {
 	CloudMariaDbInstanceNo     string     `json:"cloudMariaDbInstanceNo"`
 	CloudMariaDbServiceName    string     `json:"cloudMariaDbServiceName"`
 	CloudMariaDbInstanceStatus CommonCode `json:"cloudMariaDbInstanceStatus"`
+	CreateDate                 string     `json:"createDate"`
+	EngineVersion              string     `json:"engineVersion"`
+	VpcNo                      string     `json:"vpcNo"`
 }
 
 // --- Cloud DB for MySQL (dedicated) ---
@@ -162,6 +224,9 @@ type CloudMysqlInstance struct {
 	CloudMysqlInstanceNo     string     `json:"cloudMysqlInstanceNo"`
 	CloudMysqlServiceName    string     `json:"cloudMysqlServiceName"`
 	CloudMysqlInstanceStatus CommonCode `json:"cloudMysqlInstanceStatus"`
+	CreateDate               string     `json:"createDate"`
+	EngineVersion            string     `json:"engineVersion"`
+	VpcNo                    string     `json:"vpcNo"`
 }
 
 // --- Cloud DB for Redis (dedicated) ---
@@ -169,12 +234,16 @@ type CloudRedisInstance struct {
 	CloudRedisInstanceNo     string     `json:"cloudRedisInstanceNo"`
 	CloudRedisServiceName    string     `json:"cloudRedisServiceName"`
 	CloudRedisInstanceStatus CommonCode `json:"cloudRedisInstanceStatus"`
+	CreateDate               string     `json:"createDate"`
+	EngineVersion            string     `json:"engineVersion"`
+	VpcNo                    string     `json:"vpcNo"`
 }
 
 // --- Launch Configuration (Auto Scaling) ---
 type LaunchConfiguration struct {
 	LaunchConfigurationNo   string `json:"launchConfigurationNo"`
 	LaunchConfigurationName string `json:"launchConfigurationName"`
+	CreateDate              string `json:"createDate"`
 }
 
 // --- Network ACL ---
@@ -184,6 +253,7 @@ type NetworkAcl struct {
 	NetworkAclStatus CommonCode `json:"networkAclStatus"`
 	VpcNo            string     `json:"vpcNo"`
 	IsDefault        bool       `json:"isDefault"`
+	CreateDate       string     `json:"createDate"`
 }
 
 // --- VPC Peering ---
@@ -191,17 +261,22 @@ type VpcPeeringInstance struct {
 	VpcPeeringInstanceNo     string     `json:"vpcPeeringInstanceNo"`
 	VpcPeeringName           string     `json:"vpcPeeringName"`
 	VpcPeeringInstanceStatus CommonCode `json:"vpcPeeringInstanceStatus"`
+	SourceVpcNo              string     `json:"sourceVpcNo"`
+	TargetVpcNo              string     `json:"targetVpcNo"`
+	CreateDate               string     `json:"createDate"`
 }
 
 // --- Init Script ---
 type InitScript struct {
 	InitScriptNo   string `json:"initScriptNo"`
 	InitScriptName string `json:"initScriptName"`
+	CreateDate     string `json:"createDate"`
 }
 
 // --- Login Key ---
 type LoginKey struct {
-	KeyName string `json:"keyName"`
+	KeyName    string `json:"keyName"`
+	CreateDate string `json:"createDate"`
 }
 
 // --- Placement Group ---
@@ -215,6 +290,8 @@ type TargetGroup struct {
 	TargetGroupNo     string     `json:"targetGroupNo"`
 	TargetGroupName   string     `json:"targetGroupName"`
 	TargetGroupStatus CommonCode `json:"targetGroupStatus"`
+	VpcNo             string     `json:"vpcNo"`
+	CreateDate        string     `json:"createDate"`
 }
 
 // --- Block Storage Snapshot ---
@@ -222,6 +299,7 @@ type BlockStorageSnapshotInstance struct {
 	BlockStorageSnapshotInstanceNo     string     `json:"blockStorageSnapshotInstanceNo"`
 	BlockStorageSnapshotName           string     `json:"blockStorageSnapshotName"`
 	BlockStorageSnapshotInstanceStatus CommonCode `json:"blockStorageSnapshotInstanceStatus"`
+	CreateDate                         string     `json:"createDate"`
 }
 
 // --- NAS Volume Snapshot ---
@@ -229,95 +307,19 @@ type NasVolumeSnapshot struct {
 	NasVolumeSnapshotInstanceNo string `json:"nasVolumeSnapshotInstanceNo"`
 	NasVolumeSnapshotName       string `json:"nasVolumeSnapshotName"`
 	NasVolumeInstanceNo         string `json:"nasVolumeInstanceNo"`
+	CreateDate                  string `json:"createDate"`
 }
 
-// ListResponse wrappers for new services
-type getCloudDBInstanceListResponse struct {
-	CloudDBInstanceList []CloudDBInstance `json:"cloudDBInstanceList"`
-}
-
-type getCloudPostgresqlInstanceListResponse struct {
-	CloudPostgresqlInstanceList []CloudPostgresqlInstance `json:"cloudPostgresqlInstanceList"`
-}
-
-type getCloudMongoDbInstanceListResponse struct {
-	CloudMongoDbInstanceList []CloudMongoDbInstance `json:"cloudMongoDbInstanceList"`
-}
-
-type getVpcListResponse struct {
-	VpcList []Vpc `json:"vpcList"`
-}
-
-type getSubnetListResponse struct {
-	SubnetList []Subnet `json:"subnetList"`
-}
-
-type getNatGatewayInstanceListResponse struct {
-	NatGatewayInstanceList []NatGatewayInstance `json:"natGatewayInstanceList"`
-}
-
-type getRouteTableListResponse struct {
-	RouteTableList []RouteTable `json:"routeTableList"`
-}
-
-type getAccessControlGroupListResponse struct {
-	AccessControlGroupList []AccessControlGroup `json:"accessControlGroupList"`
-}
-
-type getAutoScalingGroupListResponse struct {
-	AutoScalingGroupList []AutoScalingGroup `json:"autoScalingGroupList"`
-}
-
+// ListResponse wrappers for REST-style APIs (v2 list APIs go through listAll)
 type getNksClusterListResponse struct {
 	Clusters []NksCluster `json:"clusters"`
 }
 
-type getCloudMariaDbInstanceListResponse struct {
-	CloudMariaDbInstanceList []CloudMariaDbInstance `json:"cloudMariaDbInstanceList"`
-}
-
-type getCloudMysqlInstanceListResponse struct {
-	CloudMysqlInstanceList []CloudMysqlInstance `json:"cloudMysqlInstanceList"`
-}
-
-type getCloudRedisInstanceListResponse struct {
-	CloudRedisInstanceList []CloudRedisInstance `json:"cloudRedisInstanceList"`
-}
-
-type getLaunchConfigurationListResponse struct {
-	LaunchConfigurationList []LaunchConfiguration `json:"launchConfigurationList"`
-}
-
-type getNetworkAclListResponse struct {
-	NetworkAclList []NetworkAcl `json:"networkAclList"`
-}
-
-type getVpcPeeringInstanceListResponse struct {
-	VpcPeeringInstanceList []VpcPeeringInstance `json:"vpcPeeringInstanceList"`
-}
-
-type getInitScriptListResponse struct {
-	InitScriptList []InitScript `json:"initScriptList"`
-}
-
-type getLoginKeyListResponse struct {
-	LoginKeyList []LoginKey `json:"loginKeyList"`
-}
-
-type getPlacementGroupListResponse struct {
-	PlacementGroupList []PlacementGroup `json:"placementGroupList"`
-}
-
-type getTargetGroupListResponse struct {
-	TargetGroupList []TargetGroup `json:"targetGroupList"`
-}
-
-type getBlockStorageSnapshotInstanceListResponse struct {
-	BlockStorageSnapshotInstanceList []BlockStorageSnapshotInstance `json:"blockStorageSnapshotInstanceList"`
-}
-
-type getNasVolumeSnapshotListResponse struct {
-	NasVolumeSnapshotList []NasVolumeSnapshot `json:"nasVolumeSnapshotList"`
+// InstanceTag is one tag of a server instance (getInstanceTagList).
+type InstanceTag struct {
+	InstanceNo string `json:"instanceNo"`
+	TagKey     string `json:"tagKey"`
+	TagValue   string `json:"tagValue"`
 }
 
 // ApiGatewayProduct represents an API Gateway product.
@@ -0,0 +1,93 @@
+package ncp
+
+import (
+	"context"
+	"time"
+
+	"ncp-nuke/pkg/progress"
+)
+
+// Defaults for waits on asynchronous NCP operations. Slow resource types set
+// their own timeout (resourceType.timeout).
+const (
+	defaultWaitTimeout  = 5 * time.Minute
+	defaultWaitInterval = 10 * time.Second
+)
+
+// waitSpec describes one polling wait.
+type waitSpec struct {
+	what     string // log subject, e.g. "NKS Cluster 삭제"
+	timeout  time.Duration
+	interval time.Duration
+}
+
+// waitUntil calls check until it reports nothing pending, ctx is done or
+// spec.timeout passes. The first check runs immediately, so a wait on an
+// operation that already finished costs a single call. It returns how many
+// were still pending at the last successful check.
+func waitUntil(ctx context.Context, spec waitSpec, check func() (int, error), l progress.Logger) int {
+	l = l.Phase(progress.PhaseWait)
+	if spec.timeout <= 0 {
+		spec.timeout = defaultWaitTimeout
+	}
+	if spec.interval <= 0 {
+		spec.interval = defaultWaitInterval
+	}
+	deadline := time.Now().Add(spec.timeout)
+	pending := -1
+	for {
+		n, err := check()
+		if err != nil {
+			l.Warn(2, "[경고] %s 상태 조회 실패: %v, 재시도...", spec.what, err)
+		} else {
+			pending = n
+			if pending == 0 {
+				l.OK(2, "%s 완료 확인", spec.what)
+				return 0
+			}
+		}
+		if !time.Now().Add(spec.interval).Before(deadline) {
+			l.Warn(2, "[경고] %s 대기 시간 초과 (%d개 남음)", spec.what, max(pending, 0))
+			return max(pending, 0)
+		}
+		if pending > 0 {
+			l.Info(2, "아직 %d개 %s 중... (%d초 후 재확인)", pending, spec.what, int(spec.interval.Seconds()))
+		}
+		if sleepCtx(ctx, spec.interval) != nil {
+			return max(pending, 0)
+		}
+	}
+}
+
+// waitList polls list until every listed resource for which pending returns
+// true has reached its terminal state (pending false) or disappeared.
+func waitList[T any](ctx context.Context, spec waitSpec, list func(context.Context) ([]T, error), pending func(T) bool, l progress.Logger) int {
+	return waitUntil(ctx, spec, func() (int, error) {
+		items, err := list(ctx)
+		if err != nil {
+			return 0, err
+		}
+		n := 0
+		for _, x := range items {
+			if pending(x) {
+				n++
+			}
+		}
+		return n, nil
+	}, l)
+}
+
+// waitServersStopped waits until the given servers (listed by list) are
+// stopped or gone.
+func waitServersStopped(ctx context.Context, list func(context.Context) ([]ServerInstance, error), serverNos []string, l progress.Logger) {
+	targets := make(map[string]bool, len(serverNos))
+	for _, no := range serverNos {
+		targets[no] = true
+	}
+	spec := waitSpec{what: "서버 정지"}
+	if left := waitList(ctx, spec, list, func(s ServerInstance) bool {
+		return targets[s.ServerInstanceNo] && s.ServerInstanceStatus.Code != "NSTOP"
+	}, l); left > 0 && ctx.Err() == nil {
+		l.Info(2, "반납을 계속 시도합니다")
+	}
+}
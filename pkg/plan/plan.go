@@ -0,0 +1,205 @@
+// Package plan builds, stores and checks deletion plans: the exact resources
+// found by a scan, which a later apply deletes and nothing else.
+package plan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"ncp-nuke/pkg/config"
+	"ncp-nuke/pkg/ncp"
+	"ncp-nuke/pkg/runner"
+)
+
+// Version is the plan file format version.
+const Version = 1
+
+// DefaultMaxAge is how old a plan may be when it is applied.
+const DefaultMaxAge = 24 * time.Hour
+
+// Plan is the set of resources to delete, per account.
+type Plan struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	Accounts  []Account `json:"accounts"`
+}
+
+// Account is the part of a plan for one root account. Credentials are never
+// stored; Fingerprint identifies the access key pair the plan was made with.
+type Account struct {
+	Name        string     `json:"name"`
+	Fingerprint string     `json:"fingerprint"`
+	Resources   []Resource `json:"resources"`
+}
+
+// Resource is one resource to delete. Order is the position of its type in
+// the deletion order (ncp.DeletionOrder); resources are listed by Order.
+type Resource struct {
+	Order  int    `json:"order"`
+	Region string `json:"region"`
+	Type   string `json:"type"` // config key of the resource type, e.g. "servers"
+	ID     string `json:"id,omitempty"`
+	Name   string `json:"name"`
+}
+
+// Build scans accounts and records what a cleanup with cfg would delete.
+// Scan errors are returned per account; the plan still holds what was found.
+func Build(ctx context.Context, accounts []ncp.RootAccount, cfg *config.Config) (*Plan, []error) {
+	order := make(map[string]int)
+	for i, rt := range ncp.DeletionOrder() {
+		order[rt.Key()] = i
+	}
+
+	p := &Plan{Version: Version, CreatedAt: time.Now().UTC()}
+	var errs []error
+	for _, acc := range accounts {
+		summaries, serrs := runner.PlanAccount(ctx, acc, cfg)
+		for _, e := range serrs {
+			errs = append(errs, fmt.Errorf("[%s] %w", acc.AccountName, e))
+		}
+		pa := Account{Name: acc.AccountName, Fingerprint: acc.Fingerprint()}
+		for _, s := range summaries {
+			for _, rt := range ncp.ResourceTypes() {
+				for _, it := range rt.Items(s) {
+					pa.Resources = append(pa.Resources, Resource{
+						Order: order[rt.Key()], Region: it.Region, Type: rt.Key(), ID: it.ID, Name: it.Name,
+					})
+				}
+			}
+		}
+		sort.SliceStable(pa.Resources, func(i, j int) bool { return pa.Resources[i].Order < pa.Resources[j].Order })
+		p.Accounts = append(p.Accounts, pa)
+	}
+	return p, errs
+}
+
+// Load reads a plan file.
+func Load(path string) (*Plan, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Parse decodes a plan and rejects unknown versions and resource types.
+func Parse(data []byte) (*Plan, error) {
+	var p Plan
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("계획 파일 해석 실패: %w", err)
+	}
+	if p.Version != Version {
+		return nil, fmt.Errorf("지원하지 않는 계획 파일 버전입니다: %d", p.Version)
+	}
+	for _, a := range p.Accounts {
+		for _, r := range a.Resources {
+			if _, ok := ncp.LookupResourceType(r.Type); !ok {
+				return nil, fmt.Errorf("[%s] 알 수 없는 리소스 종류: %s", a.Name, r.Type)
+			}
+		}
+	}
+	return &p, nil
+}
+
+// Save writes the plan to path as indented JSON.
+func (p *Plan) Save(path string) error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o600)
+}
+
+// Count returns the number of resources in the plan.
+func (p *Plan) Count() int {
+	n := 0
+	for _, a := range p.Accounts {
+		n += len(a.Resources)
+	}
+	return n
+}
+
+// Check verifies that the plan is at most maxAge old (maxAge <= 0 disables
+// the check) and that every account in it is present in accounts with the
+// same credentials. It returns the matching accounts in plan order.
+func (p *Plan) Check(accounts []ncp.RootAccount, maxAge time.Duration) ([]ncp.RootAccount, error) {
+	if age := time.Since(p.CreatedAt); maxAge > 0 && age > maxAge {
+		return nil, fmt.Errorf("계획 파일이 너무 오래되었습니다 (생성: %s, %s 경과, 허용: %s). plan을 다시 실행하세요",
+			p.CreatedAt.Local().Format("2006-01-02 15:04"), age.Round(time.Minute), maxAge)
+	}
+	byName := make(map[string]ncp.RootAccount, len(accounts))
+	for _, a := range accounts {
+		byName[a.AccountName] = a
+	}
+	var matched []ncp.RootAccount
+	var problems []string
+	for _, pa := range p.Accounts {
+		acc, ok := byName[pa.Name]
+		switch {
+		case !ok:
+			problems = append(problems, fmt.Sprintf("%s: 계정 목록에 없음", pa.Name))
+		case acc.Fingerprint() != pa.Fingerprint:
+			problems = append(problems, fmt.Sprintf("%s: 인증 키가 계획 작성 시와 다름", pa.Name))
+		default:
+			matched = append(matched, acc)
+		}
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("계획을 적용할 수 없습니다: %s", strings.Join(problems, ", "))
+	}
+	return matched, nil
+}
+
+// Account returns the part of the plan for the named account.
+func (p *Plan) Account(name string) (Account, bool) {
+	for _, a := range p.Accounts {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	return Account{}, false
+}
+
+// Config returns base (which may be nil) restricted to the account's planned
+// resources: only its regions are processed, each type's filter includes just
+// the planned ids (or names, for types without one) and unplanned types are
+// disabled. base should already be resolved for the account
+// (config.Config.ForAccount); its account and group sections are dropped.
+func (a Account) Config(base *config.Config) *config.Config {
+	var cfg config.Config
+	if base != nil {
+		cfg = *base
+	}
+	cfg.Filters = nil
+	cfg.Regions = nil
+	cfg.Accounts, cfg.Groups = nil, nil
+
+	include := make(map[string][]string)
+	seen := make(map[string]bool)
+	for _, r := range a.Resources {
+		id := r.ID
+		if id == "" {
+			id = r.Name
+		}
+		include[r.Type] = append(include[r.Type], config.Literal(id))
+		if !seen[r.Region] {
+			seen[r.Region] = true
+			cfg.Regions = append(cfg.Regions, r.Region)
+		}
+	}
+	for _, rt := range ncp.ResourceTypes() {
+		ids, ok := include[rt.Key()]
+		if !ok {
+			off := false
+			cfg.SetFilter(rt.Key(), config.ResourceFilter{Enabled: &off})
+			continue
+		}
+		cfg.SetFilter(rt.Key(), config.ResourceFilter{Include: ids})
+	}
+	return &cfg
+}
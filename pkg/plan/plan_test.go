@@ -0,0 +1,136 @@
+package plan
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"ncp-nuke/pkg/config"
+	"ncp-nuke/pkg/ncp"
+	"ncp-nuke/pkg/ncp/ncptest"
+)
+
+// testAccount starts a fake NCP server with one account holding a server
+// and two login keys, and returns the account and a config pointing at the
+// server.
+func testAccount(t *testing.T) (ncp.RootAccount, *config.Config) {
+	t.Helper()
+	srv := ncptest.NewServer()
+	t.Cleanup(srv.Close)
+	acct := srv.AddAccount("AK-"+t.Name(), "SK")
+	acct.Seed(ncptest.Servers, ncp.ServerInstance{ServerInstanceNo: "100", ServerName: "web", ServerInstanceStatus: ncp.CommonCode{Code: "RUN"}})
+	acct.Seed(ncptest.LoginKeys, ncp.LoginKey{KeyName: "lab-key"}, ncp.LoginKey{KeyName: "prod-key"})
+
+	cfg := &config.Config{Endpoints: map[string]string{}, API: config.APIConfig{RateLimit: 1000, RateBurst: 1000}}
+	for svc, u := range srv.Endpoints() {
+		cfg.Endpoints[string(svc)] = u
+	}
+	return ncp.RootAccount{AccountName: "Student-01", AccessKey: "AK-" + t.Name(), SecretKey: "SK"}, cfg
+}
+
+func TestBuild(t *testing.T) {
+	acc, cfg := testAccount(t)
+	cfg.SetFilter("login_keys", config.ResourceFilter{Exclude: []string{"prod-key"}})
+
+	p, errs := Build(context.Background(), []ncp.RootAccount{acc}, cfg)
+	if len(errs) > 0 {
+		t.Fatalf("Build errors: %v", errs)
+	}
+	a, ok := p.Account("Student-01")
+	if !ok {
+		t.Fatal("no plan for Student-01")
+	}
+	if a.Fingerprint != acc.Fingerprint() {
+		t.Errorf("fingerprint %s, want %s", a.Fingerprint, acc.Fingerprint())
+	}
+	var got []string
+	for _, r := range a.Resources {
+		got = append(got, r.Type+":"+r.Name)
+	}
+	// Servers go before the login keys they were created with.
+	if want := "servers:web,login_keys:lab-key"; strings.Join(got, ",") != want {
+		t.Errorf("resources %v, want %s", got, want)
+	}
+
+	// The plan survives a save and load.
+	path := filepath.Join(t.TempDir(), "plan.json")
+	if err := p.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Count() != 2 || !loaded.CreatedAt.Equal(p.CreatedAt) {
+		t.Errorf("loaded plan: %d resources, created %v, want 2, %v", loaded.Count(), loaded.CreatedAt, p.CreatedAt)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	acc := ncp.RootAccount{AccountName: "Student-01", AccessKey: "AK", SecretKey: "SK"}
+	p := &Plan{Version: Version, CreatedAt: time.Now(), Accounts: []Account{{Name: acc.AccountName, Fingerprint: acc.Fingerprint()}}}
+
+	matched, err := p.Check([]ncp.RootAccount{{AccountName: "other"}, acc}, DefaultMaxAge)
+	if err != nil || len(matched) != 1 || matched[0].AccountName != acc.AccountName {
+		t.Fatalf("Check = %v, %v, want the planned account", matched, err)
+	}
+
+	rotated := acc
+	rotated.SecretKey = "SK2"
+	if _, err := p.Check([]ncp.RootAccount{rotated}, DefaultMaxAge); err == nil || !strings.Contains(err.Error(), "인증 키") {
+		t.Errorf("other keys: err = %v, want a fingerprint mismatch", err)
+	}
+	if _, err := p.Check(nil, DefaultMaxAge); err == nil || !strings.Contains(err.Error(), "계정 목록에 없음") {
+		t.Errorf("missing account: err = %v", err)
+	}
+
+	p.CreatedAt = time.Now().Add(-2 * DefaultMaxAge)
+	if _, err := p.Check([]ncp.RootAccount{acc}, DefaultMaxAge); err == nil || !strings.Contains(err.Error(), "너무 오래") {
+		t.Errorf("old plan: err = %v, want it rejected", err)
+	}
+	if _, err := p.Check([]ncp.RootAccount{acc}, 0); err != nil {
+		t.Errorf("old plan without max age: %v", err)
+	}
+}
+
+func TestParse(t *testing.T) {
+	if _, err := Parse([]byte(`{"version": 99}`)); err == nil {
+		t.Error("unknown version: want an error")
+	}
+	if _, err := Parse([]byte(`{"version": 1, "accounts": [{"name": "a", "resources": [{"type": "spaceships", "name": "x"}]}]}`)); err == nil {
+		t.Error("unknown resource type: want an error")
+	}
+	if _, err := Parse([]byte(`{"version": 1, "accounts": [{"name": "a", "resources": [{"type": "servers", "id": "1", "name": "x"}]}]}`)); err != nil {
+		t.Errorf("valid plan: %v", err)
+	}
+}
+
+func TestAccountConfig(t *testing.T) {
+	a := Account{Name: "Student-01", Resources: []Resource{
+		{Region: "KR", Type: "servers", ID: "100", Name: "web"},
+		{Region: "KR", Type: "login_keys", Name: "glob:odd-key"},
+	}}
+	base := &config.Config{Workers: 2, Regions: []string{"SGN"}}
+	base.SetFilter("servers", config.ResourceFilter{Exclude: []string{"100"}})
+	cfg := a.Config(base)
+
+	if strings.Join(cfg.Regions, ",") != "KR" || cfg.Workers != 2 {
+		t.Errorf("regions %v, workers %d, want [KR] and 2", cfg.Regions, cfg.Workers)
+	}
+	servers := cfg.Filter("servers")
+	if ok, _ := servers.Evaluate(ncp.ResourceItem{Name: "web", ID: "100"}); !ok {
+		t.Error("planned server not kept")
+	}
+	if ok, _ := servers.Evaluate(ncp.ResourceItem{Name: "web2", ID: "101"}); ok {
+		t.Error("unplanned server kept")
+	}
+	keys := cfg.Filter("login_keys")
+	if ok, _ := keys.Evaluate(ncp.ResourceItem{Name: "odd-key"}); ok {
+		t.Error("a planned name is read as a pattern")
+	}
+	if vpcs := cfg.Filter("vpcs"); vpcs.IsEnabled() {
+		t.Error("unplanned type enabled")
+	}
+}
@@ -0,0 +1,217 @@
+// Package progress defines the events a run reports while it works: which
+// phase it is in, which account, region and resource an event concerns, and
+// how that step ended. Front ends render them through an EventSink, e.g. as
+// the plain text log (TextSink) or as JSON.
+package progress
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Phase is the stage of a run an Event belongs to.
+type Phase string
+
+const (
+	PhaseRun        Phase = "run"         // start of a run and its overall messages
+	PhaseAccount    Phase = "account"     // a root account starts
+	PhaseRegion     Phase = "region"      // a region of the account starts
+	PhaseScan       Phase = "scan"        // listing resources
+	PhasePrepare    Phase = "prepare"     // work before a type's deletions (stopping servers, removing routes, ...)
+	PhaseDelete     Phase = "delete"      // deleting resources
+	PhaseWait       Phase = "wait"        // waiting for asynchronous operations to finish
+	PhasePass       Phase = "pass"        // a delete pass of a region and its result
+	PhaseLeftover   Phase = "leftover"    // resources left after cleanup
+	PhaseSubAccount Phase = "sub_account" // sub account activation / deactivation
+	PhaseSummary    Phase = "summary"     // the final results of a run
+)
+
+// Status is how the step an Event reports went.
+type Status string
+
+const (
+	StatusInfo Status = "info"
+	StatusOK   Status = "ok"
+	StatusFail Status = "fail"
+	StatusSkip Status = "skip" // skipped, or waiting to retry
+	StatusWarn Status = "warn"
+)
+
+// SubAccountType is the Event.Type of sub account events.
+const SubAccountType = "sub_account"
+
+// Event is one progress report.
+type Event struct {
+	Time    time.Time `json:"time"`
+	Phase   Phase     `json:"phase"`
+	Status  Status    `json:"status"`
+	Account string    `json:"account,omitempty"`
+	Region  string    `json:"region,omitempty"`
+	Type    string    `json:"resourceType,omitempty"` // resource type config key, or SubAccountType
+	ID      string    `json:"id,omitempty"`
+	Name    string    `json:"name,omitempty"`
+	Error   string    `json:"error,omitempty"`
+
+	// Counts, for pass and summary events.
+	Success int `json:"success,omitempty"`
+	Fail    int `json:"fail,omitempty"`
+	Total   int `json:"total,omitempty"`
+
+	// Message is the human-readable text of the event and Depth its nesting
+	// level in the text log.
+	Message string `json:"text"`
+	Depth   int    `json:"depth"`
+}
+
+// EventSink receives events. Emit may be called from concurrent goroutines.
+type EventSink interface {
+	Emit(Event)
+}
+
+// SinkFunc adapts a function to an EventSink.
+type SinkFunc func(Event)
+
+// Emit calls f(e).
+func (f SinkFunc) Emit(e Event) { f(e) }
+
+// Discard is an EventSink that drops every event.
+var Discard EventSink = SinkFunc(func(Event) {})
+
+// textSink renders events as the indented text log.
+type textSink struct {
+	mu    sync.Mutex
+	logFn func(string)
+	last  Phase
+}
+
+// TextSink renders events as lines of the text log, indented two spaces per
+// level, and passes them to logFn. Each account, and the summary, starts
+// after a blank line.
+func TextSink(logFn func(string)) EventSink {
+	return &textSink{logFn: logFn}
+}
+
+func (s *textSink) Emit(e Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	line := strings.Repeat("  ", e.Depth) + e.Message
+	if e.Phase == PhaseAccount || (e.Phase == PhaseSummary && s.last != PhaseSummary) {
+		line = "\n" + line
+	}
+	s.last = e.Phase
+	s.logFn(line)
+}
+
+// Logger emits events that share a context. The fields set in Ctx (account,
+// region, phase, resource) fill in those left empty in each event; a zero
+// Logger discards everything. A Logger is itself an EventSink, so contexts
+// nest.
+type Logger struct {
+	Sink EventSink
+	Ctx  Event
+}
+
+// NewLogger returns a Logger for sink (nil discards).
+func NewLogger(sink EventSink) Logger {
+	return Logger{Sink: sink}
+}
+
+// Emit fills e from the logger's context and passes it on.
+func (l Logger) Emit(e Event) {
+	if l.Sink == nil {
+		return
+	}
+	c := l.Ctx
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	if e.Phase == "" {
+		e.Phase = c.Phase
+	}
+	if e.Status == "" {
+		e.Status = StatusInfo
+	}
+	if e.Account == "" {
+		e.Account = c.Account
+	}
+	if e.Region == "" {
+		e.Region = c.Region
+	}
+	if e.Type == "" {
+		e.Type, e.ID, e.Name = c.Type, c.ID, c.Name
+	}
+	l.Sink.Emit(e)
+}
+
+// Account returns l for the given account.
+func (l Logger) Account(name string) Logger {
+	l.Ctx.Account = name
+	return l
+}
+
+// Region returns l for the given region.
+func (l Logger) Region(code string) Logger {
+	l.Ctx.Region = code
+	return l
+}
+
+// Phase returns l for the given phase.
+func (l Logger) Phase(p Phase) Logger {
+	l.Ctx.Phase = p
+	return l
+}
+
+// Type returns l for the resources of the given type (no single resource).
+func (l Logger) Type(key string) Logger {
+	l.Ctx.Type, l.Ctx.ID, l.Ctx.Name = key, "", ""
+	return l
+}
+
+// Resource returns l for one resource.
+func (l Logger) Resource(key, id, name string) Logger {
+	l.Ctx.Type, l.Ctx.ID, l.Ctx.Name = key, id, name
+	return l
+}
+
+// Log emits a message with the given status at the given depth.
+func (l Logger) Log(depth int, status Status, format string, args ...any) {
+	l.Emit(Event{Depth: depth, Status: status, Message: sprintf(format, args...)})
+}
+
+// Info emits an informational message.
+func (l Logger) Info(depth int, format string, args ...any) {
+	l.Log(depth, StatusInfo, format, args...)
+}
+
+// OK emits a success message.
+func (l Logger) OK(depth int, format string, args ...any) {
+	l.Log(depth, StatusOK, format, args...)
+}
+
+// Warn emits a warning.
+func (l Logger) Warn(depth int, format string, args ...any) {
+	l.Log(depth, StatusWarn, format, args...)
+}
+
+// Skip emits a message about something skipped or retried later.
+func (l Logger) Skip(depth int, format string, args ...any) {
+	l.Log(depth, StatusSkip, format, args...)
+}
+
+// Fail emits a failure caused by err (which may be nil).
+func (l Logger) Fail(depth int, err error, format string, args ...any) {
+	e := Event{Depth: depth, Status: StatusFail, Message: sprintf(format, args...)}
+	if err != nil {
+		e.Error = err.Error()
+	}
+	l.Emit(e)
+}
+
+func sprintf(format string, args ...any) string {
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
@@ -0,0 +1,50 @@
+package progress
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestLoggerContext(t *testing.T) {
+	var got []Event
+	l := NewLogger(SinkFunc(func(e Event) { got = append(got, e) }))
+	region := l.Account("Student-01").Region("KR").Phase(PhaseDelete)
+
+	region.Resource("servers", "100", "web").Fail(2, errors.New("boom"), "%s 삭제 실패", "web")
+	region.Type("servers").Info(1, "서버 %d개", 1)
+	region.Emit(Event{Account: "other", Type: SubAccountType, Name: "alice"})
+
+	if len(got) != 3 {
+		t.Fatalf("%d events, want 3", len(got))
+	}
+	if e := got[0]; e.Account != "Student-01" || e.Region != "KR" || e.Phase != PhaseDelete ||
+		e.Status != StatusFail || e.ID != "100" || e.Error != "boom" || e.Message != "web 삭제 실패" || e.Depth != 2 || e.Time.IsZero() {
+		t.Errorf("resource failure: %+v", e)
+	}
+	if e := got[1]; e.Type != "servers" || e.ID != "" || e.Status != StatusInfo {
+		t.Errorf("type message: %+v, want the type without a resource", e)
+	}
+	// Fields set in the event win over the context.
+	if e := got[2]; e.Account != "other" || e.Type != SubAccountType || e.Name != "alice" || e.ID != "" {
+		t.Errorf("explicit event: %+v", e)
+	}
+
+	// A zero logger discards.
+	Logger{}.Warn(0, "dropped")
+}
+
+func TestTextSink(t *testing.T) {
+	var lines []string
+	l := NewLogger(TextSink(func(s string) { lines = append(lines, s) }))
+
+	l.Phase(PhaseAccount).Info(0, "[계정] Student-01")
+	l.Phase(PhaseScan).Info(1, "리소스 조회 %d%%", 100)
+	l.Phase(PhaseSummary).Info(0, "요약")
+	l.Phase(PhaseSummary).Info(1, "삭제 3")
+
+	want := []string{"\n[계정] Student-01", "  리소스 조회 100%", "\n요약", "  삭제 3"}
+	if strings.Join(lines, "|") != strings.Join(want, "|") {
+		t.Errorf("lines %q, want %q", lines, want)
+	}
+}
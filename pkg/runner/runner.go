@@ -1,110 +1,298 @@
 // Package runner contains the core account/resource processing logic shared by
-// the TUI and the web application. It is UI-agnostic: progress is reported via a
-// logFn callback.
+// the TUI and the web application. It is UI-agnostic: progress is reported as
+// events to a progress.EventSink.
 package runner
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
+	"sync"
 
 	"ncp-nuke/pkg/config"
+	"ncp-nuke/pkg/journal"
 	"ncp-nuke/pkg/ncp"
+	"ncp-nuke/pkg/progress"
 )
 
-// formatResourceErr classifies a resource-listing error. A 403 / access-key
-// rejection means the API key lacks permission for that product (or the product
-// is unused / not enabled), which is expected for many accounts, so it is shown
-// as a benign skip rather than a warning.
-func formatResourceErr(e error) string {
-	msg := e.Error()
-	// 403/access-key → no permission; 404/URL not found → product not available
-	// on this account/platform (e.g. classic-only Cloud DB, MariaDB). Both are
-	// expected for many accounts, so show as a benign skip, not a warning.
-	for _, sig := range []string{"HTTP 403", "StatusCode: 403", "AccessDenied", "InvalidAccessKeyId",
-		"HTTP 404", "URL not found", "Not Found Exception"} {
-		if strings.Contains(msg, sig) {
-			return fmt.Sprintf("    [건너뜀] 권한 없음/미지원: %v", e)
+// logResourceErr reports a resource-listing error. Permission denied (the API
+// key lacks permission for that product, or it is not enabled) and not found
+// (the product is unavailable on this account/platform, e.g. classic-only
+// Cloud DB, MariaDB) are expected for many accounts, so they are shown as a
+// benign skip rather than a warning.
+func logResourceErr(l progress.Logger, e error) {
+	if ncp.IsPermissionDenied(e) || ncp.IsNotFound(e) {
+		l.Emit(progress.Event{Depth: 2, Status: progress.StatusSkip, Error: e.Error(), Message: fmt.Sprintf("[건너뜀] 권한 없음/미지원: %v", e)})
+		return
+	}
+	l.Emit(progress.Event{Depth: 2, Status: progress.StatusWarn, Error: e.Error(), Message: fmt.Sprintf("[경고] 조회 오류: %v", e)})
+}
+
+// SiteOf returns the cloud site for account: its own Site, else the
+// configured default (of its account or group section first), else public.
+func SiteOf(account ncp.RootAccount, cfg *config.Config) ncp.Site {
+	if account.Site != "" {
+		return account.Site
+	}
+	cfg = cfg.ForAccount(account)
+	if cfg != nil {
+		if s, err := ncp.ParseSite(cfg.Site); err == nil {
+			return s
+		}
+	}
+	return ncp.SitePublic
+}
+
+// PlatformOf returns the platforms to cover for account: its own Platform,
+// else the configured default (of its account or group section first), else
+// auto.
+func PlatformOf(account ncp.RootAccount, cfg *config.Config) ncp.Platform {
+	if account.Platform != "" {
+		return account.Platform
+	}
+	cfg = cfg.ForAccount(account)
+	if cfg != nil {
+		if p, err := ncp.ParsePlatform(cfg.Platform); err == nil {
+			return p
 		}
 	}
-	return fmt.Sprintf("    [경고] 조회 오류: %v", e)
+	return ncp.PlatformAuto
+}
+
+// NewClient builds an API client for account on its cloud site and platform,
+// applying any endpoint, retry, rate-limit and page-size overrides from cfg
+// (cfg may be nil, and is resolved for account) and then extra.
+func NewClient(account ncp.RootAccount, cfg *config.Config, extra ...ncp.Option) *ncp.Client {
+	cfg = cfg.ForAccount(account)
+	opts := []ncp.Option{ncp.WithSite(SiteOf(account, cfg)), ncp.WithPlatform(PlatformOf(account, cfg))}
+	if cfg != nil {
+		for svc, u := range cfg.Endpoints {
+			opts = append(opts, ncp.WithEndpoint(ncp.Service(svc), u))
+		}
+		if cfg.API.MaxAttempts > 0 {
+			p := ncp.DefaultRetryPolicy
+			p.MaxAttempts = cfg.API.MaxAttempts
+			opts = append(opts, ncp.WithRetryPolicy(p))
+		}
+		if cfg.API.RateLimit > 0 {
+			burst := cfg.API.RateBurst
+			if burst <= 0 {
+				burst = ncp.DefaultRateBurst
+			}
+			opts = append(opts, ncp.WithRateLimit(cfg.API.RateLimit, burst))
+		}
+		if cfg.API.PageSize > 0 {
+			opts = append(opts, ncp.WithPageSize(cfg.API.PageSize))
+		}
+		if cfg.Workers > 0 {
+			opts = append(opts, ncp.WithWorkers(cfg.Workers))
+		}
+	}
+	opts = append(opts, extra...)
+	return ncp.NewClient(account.AccessKey, account.SecretKey, opts...)
+}
+
+// ResolveIdentities fills in the MemberNo of every account from its access
+// key, in parallel. Accounts whose member number cannot be read keep "".
+func ResolveIdentities(ctx context.Context, accounts []ncp.RootAccount, cfg *config.Config) {
+	var wg sync.WaitGroup
+	for i := range accounts {
+		if accounts[i].MemberNo != "" {
+			continue
+		}
+		wg.Add(1)
+		go func(a *ncp.RootAccount) {
+			defer wg.Done()
+			a.MemberNo, _ = NewClient(*a, cfg).MemberNo(ctx)
+		}(&accounts[i])
+	}
+	wg.Wait()
+}
+
+// CheckIdentity refuses account when cfg's account guard does not allow it,
+// resolving its member number first if it is not known yet.
+func CheckIdentity(ctx context.Context, account ncp.RootAccount, cfg *config.Config) error {
+	if !config.Guarded(cfg) {
+		return nil
+	}
+	memberNo := account.MemberNo
+	if memberNo == "" {
+		no, err := NewClient(account, cfg).MemberNo(ctx)
+		if err != nil {
+			return fmt.Errorf("%w (account_guard 가 설정되어 있어 이 계정은 건드리지 않습니다)", err)
+		}
+		memberNo = no
+	}
+	return config.CheckAccount(cfg, memberNo)
+}
+
+// Result is the outcome of a Process run.
+type Result struct {
+	Success, Fail               int      // sub account operations
+	CleanupSuccess, CleanupFail int      // resource deletions
+	Dirty                       []string // accounts with resources left after cleanup
+	Failed                      []string // accounts whose regions, resources or sub accounts could not be listed or found
+	Cancelled                   bool
+
+	Resources []ResourceRecord // what a list run found, after filters
+}
+
+// ResourceRecord is one resource found by a list run.
+type ResourceRecord struct {
+	Account  string `json:"account" yaml:"account"`
+	Region   string `json:"region" yaml:"region"`
+	Type     string `json:"type" yaml:"type"` // config key of the resource type
+	ID       string `json:"id" yaml:"id"`
+	Name     string `json:"name" yaml:"name"`
+	Status   string `json:"status" yaml:"status"`
+	Created  string `json:"created" yaml:"created"`
+	VpcNo    string `json:"vpcNo" yaml:"vpcNo"`
+	SubnetNo string `json:"subnetNo" yaml:"subnetNo"`
+
+	Tags map[string]string `json:"tags,omitempty" yaml:"tags,omitempty"`
+
+	// Kept tells whether the filters keep the resource (a cleanup would
+	// delete it) and Rule which filter rule decided.
+	Kept bool   `json:"kept" yaml:"kept"`
+	Rule string `json:"rule" yaml:"rule"`
+}
+
+// resourceRecords turns the filter decisions of a region into records of
+// account.
+func resourceRecords(account string, decisions []filterDecision) []ResourceRecord {
+	out := make([]ResourceRecord, 0, len(decisions))
+	for _, d := range decisions {
+		it := d.item
+		out = append(out, ResourceRecord{
+			Account: account, Region: it.Region, Type: d.typeKey, ID: it.ID, Name: it.Name,
+			Status: it.Status, Created: it.Created, VpcNo: it.VpcNo, SubnetNo: it.SubnetNo,
+			Tags: it.Tags, Kept: d.kept, Rule: d.rule,
+		})
+	}
+	return out
+}
+
+// Clean reports whether everything the run attempted succeeded.
+func (r Result) Clean() bool {
+	return r.Fail == 0 && r.CleanupFail == 0 && len(r.Dirty) == 0 && len(r.Failed) == 0 && !r.Cancelled
+}
+
+// Add adds the counts and account lists of o to r, for runs made of several
+// Process calls.
+func (r *Result) Add(o Result) {
+	r.Success += o.Success
+	r.Fail += o.Fail
+	r.CleanupSuccess += o.CleanupSuccess
+	r.CleanupFail += o.CleanupFail
+	r.Dirty = append(r.Dirty, o.Dirty...)
+	r.Failed = append(r.Failed, o.Failed...)
+	r.Cancelled = r.Cancelled || o.Cancelled
+	r.Resources = append(r.Resources, o.Resources...)
 }
 
 // Process runs the selected action against the selected accounts.
 // action is one of: "activate", "deactivate", "nuke", "list".
 // ctx cancellation stops launching further work (in-flight API calls finish).
-func Process(ctx context.Context, accounts []ncp.RootAccount, selected map[int]bool, action, globalPassword string, cleanup bool, cfg *config.Config, logFn func(string)) {
-	logFn("작업 시작...")
+// Every operation is recorded in j (nil for no journal); sub account
+// operations j already holds as succeeded are skipped. Progress is reported
+// to sink. Each account runs with cfg resolved for it (Config.ForAccount).
+func Process(ctx context.Context, accounts []ncp.RootAccount, selected map[int]bool, action, globalPassword string, cleanup bool, cfg *config.Config, j *journal.Writer, sink progress.EventSink) Result {
+	l := progress.NewLogger(sink).Phase(progress.PhaseRun)
+	l.Info(0, "작업 시작...")
 
-	totalSuccess, totalFail := 0, 0
-	totalCleanupSuccess, totalCleanupFail := 0, 0
+	var res Result
 
 	for i, account := range accounts {
 		if !selected[i] {
 			continue
 		}
 		if ctx.Err() != nil {
-			logFn("\n[취소됨] 작업이 취소되었습니다.")
-			return
+			l.Phase(progress.PhaseSummary).Warn(0, "[취소됨] 작업이 취소되었습니다.")
+			res.Cancelled = true
+			return res
 		}
 
-		logFn(fmt.Sprintf("\n[루트 계정: %s]", account.AccountName))
-		client := ncp.NewClient(account.AccessKey, account.SecretKey)
-
-		// Read-only resource listing.
-		if action == "list" {
-			logFn("  리소스 조회 중...")
-			summary, errs := client.ListAllResources()
-			for _, e := range errs {
-				logFn(formatResourceErr(e))
-			}
-			if cfg != nil {
-				applyFilter(summary, cfg)
-			}
-			if summary.TotalCount() == 0 {
-				logFn("  리소스 없음")
-			} else {
-				logFn(fmt.Sprintf("  총 %d개 리소스:", summary.TotalCount()))
-				for _, bc := range summary.Breakdown() {
-					logFn(fmt.Sprintf("    - %s: %d개", bc.Name, bc.Count))
-				}
-			}
+		l := l.Account(account.AccountName) // the account's events
+		cfg := cfg.ForAccount(account)
+		l.Phase(progress.PhaseAccount).Info(0, "[루트 계정: %s]", account.AccountName)
+		if err := CheckIdentity(ctx, account, cfg); err != nil {
+			l.Phase(progress.PhaseAccount).Fail(1, err, "[차단] %v", err)
+			res.Failed = append(res.Failed, account.AccountName)
 			continue
 		}
-
-		// Cleanup phase (deactivate + cleanup, or standalone nuke)
-		if (action == "deactivate" && cleanup) || action == "nuke" {
-			logFn("  리소스 조회 중...")
-			summary, errs := client.ListAllResources()
-			for _, e := range errs {
-				logFn(formatResourceErr(e))
+		journalStart(j, account, action, globalPassword, cleanup, cfg, l)
+		client := NewClient(account, cfg, ncp.WithJournal(journalDelete(j, account.AccountName)))
+		// finish marks the account as done in the journal, unless the run
+		// was cancelled midway or resources were left (a resume retries it).
+		complete := true
+		finish := func() {
+			if complete && ctx.Err() == nil {
+				journalAppend(j, journal.Record{Kind: journal.KindAccountEnd, Account: account.AccountName, Action: action}, l)
 			}
+		}
 
-			if cfg != nil {
-				applyFilter(summary, cfg)
+		// Resource listing / cleanup runs once per selected region.
+		if action == "list" || (action == "deactivate" && cleanup) || action == "nuke" {
+			regions, err := ResolveRegions(ctx, client, cfg)
+			if err != nil {
+				l.Phase(progress.PhaseRegion).Fail(2, err, "[실패] 리전 조회: %v", err)
+				res.Failed = append(res.Failed, account.AccountName)
+				complete = false
 			}
-
-			if summary.TotalCount() > 0 {
-				logFn(fmt.Sprintf("  총 %d개 서비스 해지 및 리소스 삭제 시작...", summary.TotalCount()))
-				s, f := client.CleanupAllResources(ctx, summary, logFn)
-				totalCleanupSuccess += s
-				totalCleanupFail += f
-				logFn(fmt.Sprintf("  서비스 해지 및 리소스 삭제 결과: 성공 %d, 실패 %d", s, f))
-			} else {
-				logFn("  삭제할 리소스 없음")
+			var leftovers []*ncp.ResourceSummary
+			unverified := false
+			for _, region := range regions {
+				if ctx.Err() != nil {
+					break
+				}
+				rc := client.ForRegion(region)
+				rl := l.Region(region)
+				if len(regions) > 1 || region != "" {
+					rl.Phase(progress.PhaseRegion).Info(1, "[리전: %s]", region)
+				}
+				if action == "list" {
+					decisions := listRegion(ctx, rc, cfg, rl)
+					res.Resources = append(res.Resources, resourceRecords(account.AccountName, decisions)...)
+					continue
+				}
+				s, f, left, err := cleanupRegion(ctx, rc, cfg, rl)
+				res.CleanupSuccess += s
+				res.CleanupFail += f
+				if left != nil {
+					leftovers = append(leftovers, left)
+				}
+				if err != nil {
+					// Not verified clean: a resume must retry the account.
+					rl.Phase(progress.PhaseScan).Fail(1, err, "[실패] 리소스를 모두 조회하지 못해 정리 여부를 확인할 수 없습니다: %v", err)
+					unverified = true
+				}
+			}
+			if action != "list" && ctx.Err() == nil && err == nil {
+				if logLeftovers(leftovers, l) > 0 {
+					res.Dirty = append(res.Dirty, account.AccountName)
+					complete = false
+				}
+				if unverified {
+					res.Failed = append(res.Failed, account.AccountName)
+					complete = false
+				}
 			}
 		}
-
-		// Nuke only targets resources; sub accounts are left untouched.
-		if action == "nuke" {
+		// List and nuke only target resources; sub accounts are left untouched.
+		if action == "list" || action == "nuke" {
+			finish()
 			continue
 		}
 
 		// Sub account operation
-		logFn("  서브 계정 조회 중...")
-		subAccounts, err := client.ListSubAccounts()
+		l = l.Phase(progress.PhaseSubAccount).Type(progress.SubAccountType)
+		l.Info(1, "서브 계정 조회 중...")
+		subAccounts, err := client.ListSubAccounts(ctx)
 		if err != nil {
-			logFn(fmt.Sprintf("    [실패] 서브 계정 조회: %v", err))
+			l.Fail(2, err, "[실패] 서브 계정 조회: %v", err)
+			res.Failed = append(res.Failed, account.AccountName)
 			continue
 		}
 
@@ -126,9 +314,11 @@ func Process(ctx context.Context, accounts []ncp.RootAccount, selected map[int]b
 				for _, sa := range subAccounts {
 					ids = append(ids, sa.LoginId)
 				}
-				logFn(fmt.Sprintf("    [오류] 지정된 IAM 사용자(%s)를 찾을 수 없습니다. 존재하는 LoginId: %v", account.IamUsername, ids))
+				l.Fail(2, nil, "[오류] 지정된 IAM 사용자(%s)를 찾을 수 없습니다. 존재하는 LoginId: %v", account.IamUsername, ids)
+				res.Failed = append(res.Failed, account.AccountName)
 			} else {
-				logFn("    대상 서브 계정 없음")
+				l.Info(2, "대상 서브 계정 없음")
+				finish()
 			}
 			continue
 		}
@@ -136,284 +326,507 @@ func Process(ctx context.Context, accounts []ncp.RootAccount, selected map[int]b
 		switch action {
 		case "activate":
 			for _, sa := range targets {
+				sl := l.Resource(progress.SubAccountType, sa.LoginId, sa.Name)
+				op := subAccountOp(account, sa, action)
+				if j.Succeeded(op) {
+					sl.Skip(2, "[건너뜀] %s: 이전 실행에서 완료됨", sa.LoginId)
+					res.Success++
+					continue
+				}
 				effectivePassword := account.Password
 				if effectivePassword == "" {
 					effectivePassword = globalPassword
 				}
-				generatedPw, err := client.ActivateSubAccount(sa, effectivePassword)
+				journalAppend(j, op, sl)
+				generatedPw, err := client.ActivateSubAccount(ctx, sa, effectivePassword)
+				journalAppend(j, opResult(op, err), sl)
 				if err != nil {
-					logFn(fmt.Sprintf("    [실패] %s (%s): %v", sa.LoginId, sa.Name, err))
-					totalFail++
+					sl.Fail(2, err, "[실패] %s (%s): %v", sa.LoginId, sa.Name, err)
+					res.Fail++
 				} else {
 					if generatedPw != "" {
-						logFn(fmt.Sprintf("    [성공] %s (%s): 활성화 + 비밀번호 초기화 완료 (생성된 비밀번호: %s)", sa.LoginId, sa.Name, generatedPw))
+						sl.OK(2, "[성공] %s (%s): 활성화 + 비밀번호 초기화 완료 (생성된 비밀번호: %s)", sa.LoginId, sa.Name, generatedPw)
 					} else {
-						logFn(fmt.Sprintf("    [성공] %s (%s): 활성화 + 비밀번호 초기화 완료", sa.LoginId, sa.Name))
+						sl.OK(2, "[성공] %s (%s): 활성화 + 비밀번호 초기화 완료", sa.LoginId, sa.Name)
 					}
-					totalSuccess++
+					res.Success++
 				}
 			}
 
 		case "deactivate":
 			for _, sa := range targets {
+				sl := l.Resource(progress.SubAccountType, sa.LoginId, sa.Name)
 				if !sa.Active {
-					logFn(fmt.Sprintf("    [건너뜀] %s: 이미 비활성", sa.LoginId))
-					totalSuccess++
+					sl.Skip(2, "[건너뜀] %s: 이미 비활성", sa.LoginId)
+					res.Success++
 					continue
 				}
-				if err := client.DeactivateSubAccount(sa); err != nil {
-					logFn(fmt.Sprintf("    [실패] %s 비활성화: %v", sa.LoginId, err))
-					totalFail++
+				op := subAccountOp(account, sa, action)
+				journalAppend(j, op, sl)
+				err := client.DeactivateSubAccount(ctx, sa)
+				journalAppend(j, opResult(op, err), sl)
+				if err != nil {
+					sl.Fail(2, err, "[실패] %s 비활성화: %v", sa.LoginId, err)
+					res.Fail++
 				} else {
-					logFn(fmt.Sprintf("    [성공] %s 비활성화 완료", sa.LoginId))
-					totalSuccess++
+					sl.OK(2, "[성공] %s 비활성화 완료", sa.LoginId)
+					res.Success++
 				}
 			}
 		}
+		finish()
 	}
 
+	res.Cancelled = ctx.Err() != nil
+	l = l.Phase(progress.PhaseSummary)
 	if action == "list" {
-		logFn("\n리소스 조회 완료")
-		return
+		l.Info(0, "리소스 조회 완료")
+		return res
 	}
 
 	if action == "nuke" {
-		logFn(fmt.Sprintf("\n최종 결과: 리소스 삭제 성공 %d, 실패 %d", totalCleanupSuccess, totalCleanupFail))
-		return
+		logTotals(l, "최종 결과: 리소스 삭제", res.CleanupSuccess, res.CleanupFail)
+		logDirtyAccounts(res.Dirty, l)
+		return res
 	}
 
 	actionLabel := "활성화"
 	if action == "deactivate" {
 		actionLabel = "비활성화"
 	}
-	logFn(fmt.Sprintf("\n최종 결과: 서브계정 %s 성공 %d, 실패 %d", actionLabel, totalSuccess, totalFail))
+	logTotals(l, "최종 결과: 서브계정 "+actionLabel, res.Success, res.Fail)
 	if cleanup {
-		logFn(fmt.Sprintf("리소스 삭제 성공 %d, 실패 %d", totalCleanupSuccess, totalCleanupFail))
+		logTotals(l, "리소스 삭제", res.CleanupSuccess, res.CleanupFail)
+		logDirtyAccounts(res.Dirty, l)
 	}
+	return res
 }
 
-func applyFilter(summary *ncp.ResourceSummary, cfg *config.Config) {
-	var servers []ncp.ServerInstance
-	for _, s := range summary.Servers {
-		if cfg.Servers.Match(s.ServerName, s.ServerInstanceNo) {
-			servers = append(servers, s)
-		}
+// NewJournal starts the operation journal of a run in cfg's journal
+// directory (cfg may be nil). It returns nil when cfg disables the journal.
+func NewJournal(cfg *config.Config) (*journal.Writer, error) {
+	dir, ok := config.JournalDirOf(cfg)
+	if !ok {
+		return nil, nil
 	}
-	summary.Servers = servers
+	return journal.Create(dir)
+}
 
-	var storages []ncp.BlockStorageInstance
-	for _, s := range summary.BlockStorages {
-		if cfg.BlockStorages.Match(s.BlockStorageName, s.BlockStorageInstanceNo) {
-			storages = append(storages, s)
-		}
+// journalStart records that the account's work starts, with everything a
+// resume needs to repeat it. Passwords are never written: Password only notes
+// that a common one was given.
+func journalStart(j *journal.Writer, account ncp.RootAccount, action, globalPassword string, cleanup bool, cfg *config.Config, l progress.Logger) {
+	if j == nil {
+		return
 	}
-	summary.BlockStorages = storages
-
-	var bsSnaps []ncp.BlockStorageSnapshotInstance
-	for _, s := range summary.BlockStorageSnapshots {
-		if cfg.BlockStorageSnapshots.Match(s.BlockStorageSnapshotName, s.BlockStorageSnapshotInstanceNo) {
-			bsSnaps = append(bsSnaps, s)
-		}
+	r := journal.Record{
+		Kind:        journal.KindAccount,
+		Account:     account.AccountName,
+		Fingerprint: account.Fingerprint(),
+		Action:      action,
+		Cleanup:     cleanup,
+		Password:    globalPassword != "",
 	}
-	summary.BlockStorageSnapshots = bsSnaps
-
-	var ips []ncp.PublicIpInstance
-	for _, s := range summary.PublicIps {
-		if cfg.PublicIps.Match(s.PublicIp, s.PublicIpInstanceNo) {
-			ips = append(ips, s)
+	if cfg != nil {
+		data, err := json.Marshal(cfg)
+		if err != nil {
+			l.Warn(2, "[경고] 작업 저널에 설정 기록 실패: %v", err)
 		}
+		r.Config = data
 	}
-	summary.PublicIps = ips
+	journalAppend(j, r, l)
+}
 
-	var vols []ncp.NasVolumeInstance
-	for _, s := range summary.NasVolumes {
-		if cfg.NasVolumes.Match(s.VolumeName, s.NasVolumeInstanceNo) {
-			vols = append(vols, s)
-		}
+// journalDelete records the deletions of the account's cleanup in j.
+func journalDelete(j *journal.Writer, account string) func(ncp.Operation) {
+	if j == nil {
+		return nil
 	}
-	summary.NasVolumes = vols
-
-	var nasSnaps []ncp.NasVolumeSnapshot
-	for _, s := range summary.NasVolumeSnapshots {
-		if cfg.NasVolumeSnapshots.Match(s.NasVolumeSnapshotName, s.NasVolumeSnapshotInstanceNo) {
-			nasSnaps = append(nasSnaps, s)
+	return func(op ncp.Operation) {
+		r := journal.Record{
+			Kind: journal.KindOperation, Account: account, Op: "delete",
+			Region: op.Region, Type: op.Type, ID: op.ID, Name: op.Name, Status: string(op.Status),
 		}
-	}
-	summary.NasVolumeSnapshots = nasSnaps
-
-	var lbs []ncp.LoadBalancerInstance
-	for _, s := range summary.LoadBalancers {
-		if cfg.LoadBalancers.Match(s.LoadBalancerName, s.LoadBalancerInstanceNo) {
-			lbs = append(lbs, s)
+		if op.Err != nil {
+			r.Error = op.Err.Error()
 		}
+		// Write failures surface on the next sequential record of the account.
+		j.Append(r)
 	}
-	summary.LoadBalancers = lbs
+}
 
-	var tgs []ncp.TargetGroup
-	for _, s := range summary.TargetGroups {
-		if cfg.TargetGroups.Match(s.TargetGroupName, s.TargetGroupNo) {
-			tgs = append(tgs, s)
-		}
+// subAccountOp is the journal record of action on a sub account, before it
+// is attempted.
+func subAccountOp(account ncp.RootAccount, sa ncp.SubAccount, action string) journal.Record {
+	return journal.Record{
+		Kind: journal.KindOperation, Account: account.AccountName, Op: action,
+		Type: journal.SubAccountType, ID: sa.LoginId, Name: sa.Name, Status: journal.StatusRequested,
 	}
-	summary.TargetGroups = tgs
+}
 
-	var dbs []ncp.CloudDBInstance
-	for _, s := range summary.CloudDBs {
-		if cfg.CloudDBs.Match(s.CloudDBServiceName, s.CloudDBInstanceNo) {
-			dbs = append(dbs, s)
-		}
+// opResult returns op with the outcome of the attempt.
+func opResult(op journal.Record, err error) journal.Record {
+	op.Status = journal.StatusOK
+	if err != nil {
+		op.Status, op.Error = journal.StatusFailed, err.Error()
 	}
-	summary.CloudDBs = dbs
+	return op
+}
 
-	var pgs []ncp.CloudPostgresqlInstance
-	for _, s := range summary.CloudPostgresqls {
-		if cfg.CloudPostgresqls.Match(s.CloudPostgresqlServiceName, s.CloudPostgresqlInstanceNo) {
-			pgs = append(pgs, s)
-		}
+// journalAppend writes r to j, logging a failure.
+func journalAppend(j *journal.Writer, r journal.Record, l progress.Logger) {
+	if err := j.Append(r); err != nil {
+		l.Warn(2, "[경고] 작업 저널 기록 실패: %v", err)
 	}
-	summary.CloudPostgresqls = pgs
+}
 
-	var mgs []ncp.CloudMongoDbInstance
-	for _, s := range summary.CloudMongoDBs {
-		if cfg.CloudMongoDBs.Match(s.CloudMongoDbServiceName, s.CloudMongoDbInstanceNo) {
-			mgs = append(mgs, s)
-		}
+// VerifyInFlight settles the operations of account that an interrupted run
+// requested without recording a result. A deletion whose resource is gone is
+// recorded as verified; one whose resource still exists is recorded as failed
+// and is retried by the resumed run. Sub account operations are simply redone.
+// Progress is reported to sink.
+func VerifyInFlight(ctx context.Context, account ncp.RootAccount, cfg *config.Config, ops []journal.Record, j *journal.Writer, sink progress.EventSink) {
+	if len(ops) == 0 {
+		return
 	}
-	summary.CloudMongoDBs = mgs
-
-	var mdbs []ncp.CloudMariaDbInstance
-	for _, s := range summary.CloudMariaDBs {
-		if cfg.CloudMariaDBs.Match(s.CloudMariaDbServiceName, s.CloudMariaDbInstanceNo) {
-			mdbs = append(mdbs, s)
+	l := progress.NewLogger(sink).Phase(progress.PhaseScan).Account(account.AccountName)
+	l.Info(1, "중단 시점에 진행 중이던 작업 %d개 확인 중...", len(ops))
+	byRegion := make(map[string][]journal.Record)
+	var regions []string
+	for _, op := range ops {
+		if op.Type == journal.SubAccountType {
+			l.Resource(op.Type, op.ID, op.Name).Info(2, "[재시도] 서브 계정 %s: %s 결과 미기록, 다시 수행합니다", op.ID, op.Op)
+			continue
 		}
-	}
-	summary.CloudMariaDBs = mdbs
-
-	var mysqls []ncp.CloudMysqlInstance
-	for _, s := range summary.CloudMySQLs {
-		if cfg.CloudMySQLs.Match(s.CloudMysqlServiceName, s.CloudMysqlInstanceNo) {
-			mysqls = append(mysqls, s)
+		if _, ok := byRegion[op.Region]; !ok {
+			regions = append(regions, op.Region)
 		}
+		byRegion[op.Region] = append(byRegion[op.Region], op)
 	}
-	summary.CloudMySQLs = mysqls
 
-	var redises []ncp.CloudRedisInstance
-	for _, s := range summary.CloudRedises {
-		if cfg.CloudRedises.Match(s.CloudRedisServiceName, s.CloudRedisInstanceNo) {
-			redises = append(redises, s)
+	client := NewClient(account, cfg)
+	for _, region := range regions {
+		if ctx.Err() != nil {
+			return
 		}
-	}
-	summary.CloudRedises = redises
-
-	var vpcs []ncp.Vpc
-	for _, s := range summary.Vpcs {
-		if cfg.Vpcs.Match(s.VpcName, s.VpcNo) {
-			vpcs = append(vpcs, s)
+		summary, errs := client.ForRegion(region).ListAllResources(ctx)
+		present := make(map[string]bool)
+		for _, rt := range ncp.ResourceTypes() {
+			for _, it := range rt.Items(summary) {
+				present[rt.Key()+"\x00"+it.Name+"\x00"+it.ID] = true
+			}
 		}
-	}
-	summary.Vpcs = vpcs
-
-	var subnets []ncp.Subnet
-	for _, s := range summary.Subnets {
-		if cfg.Subnets.Match(s.SubnetName, s.SubnetNo) {
-			subnets = append(subnets, s)
+		for _, op := range byRegion[region] {
+			label := op.Type
+			if rt, ok := ncp.LookupResourceType(op.Type); ok {
+				label = rt.Name()
+			}
+			label = fmt.Sprintf("[%s] %s: %s", region, label, op.Name)
+			ol := l.Region(region).Resource(op.Type, op.ID, op.Name)
+			switch {
+			case present[op.Type+"\x00"+op.Name+"\x00"+op.ID]:
+				op.Status, op.Error = journal.StatusFailed, "재개 시 리소스가 남아 있음"
+				journalAppend(j, op, ol)
+				ol.Skip(2, "[미완료] %s - 다시 삭제합니다", label)
+			case len(errs) > 0:
+				ol.Warn(2, "[확인 불가] %s - 조회 오류로 삭제 여부를 확인하지 못했습니다", label)
+			default:
+				op.Status = journal.StatusVerified
+				journalAppend(j, op, ol)
+				ol.OK(2, "[완료 확인] %s", label)
+			}
 		}
 	}
-	summary.Subnets = subnets
+}
 
-	var nats []ncp.NatGatewayInstance
-	for _, s := range summary.NatGateways {
-		if cfg.NatGateways.Match(s.NatGatewayName, s.NatGatewayInstanceNo) {
-			nats = append(nats, s)
-		}
-	}
-	summary.NatGateways = nats
+// logTotals reports the success and fail counts of what.
+func logTotals(l progress.Logger, what string, success, fail int) {
+	l.Emit(progress.Event{
+		Success: success, Fail: fail, Total: success + fail,
+		Message: fmt.Sprintf("%s 성공 %d, 실패 %d", what, success, fail),
+	})
+}
 
-	var peerings []ncp.VpcPeeringInstance
-	for _, s := range summary.VpcPeerings {
-		if cfg.VpcPeerings.Match(s.VpcPeeringName, s.VpcPeeringInstanceNo) {
-			peerings = append(peerings, s)
-		}
+// logDirtyAccounts names the accounts that still have resources after cleanup.
+func logDirtyAccounts(accounts []string, l progress.Logger) {
+	if len(accounts) > 0 {
+		l.Warn(0, "[경고] 잔여 리소스가 남은 계정: %s", strings.Join(accounts, ", "))
 	}
-	summary.VpcPeerings = peerings
+}
 
-	var nacls []ncp.NetworkAcl
-	for _, s := range summary.NetworkAcls {
-		if cfg.NetworkAcls.Match(s.NetworkAclName, s.NetworkAclNo) {
-			nacls = append(nacls, s)
+// ResolveRegions returns the region codes to process for an account. Without
+// configured regions it returns [""] (the client's default region, no
+// regionCode sent); "all" expands to every region from getRegionList.
+func ResolveRegions(ctx context.Context, client *ncp.Client, cfg *config.Config) ([]string, error) {
+	if cfg == nil || len(cfg.Regions) == 0 {
+		return []string{""}, nil
+	}
+	if len(cfg.Regions) == 1 && strings.EqualFold(cfg.Regions[0], config.AllRegions) {
+		regions, err := client.ListRegions(ctx)
+		if err != nil {
+			return nil, err
 		}
+		var codes []string
+		for _, r := range regions {
+			codes = append(codes, r.RegionCode)
+		}
+		return codes, nil
 	}
-	summary.NetworkAcls = nacls
+	return cfg.Regions, nil
+}
 
-	var acgs []ncp.AccessControlGroup
-	for _, s := range summary.AccessControlGroups {
-		if cfg.AccessControlGroups.Match(s.AccessControlGroupName, s.AccessControlGroupNo) {
-			acgs = append(acgs, s)
+// ScanAccount lists the resources of account in every region selected by cfg,
+// one summary per region. Filters are not applied.
+func ScanAccount(ctx context.Context, account ncp.RootAccount, cfg *config.Config) ([]*ncp.ResourceSummary, []error) {
+	cfg = cfg.ForAccount(account)
+	client := NewClient(account, cfg)
+	regions, err := ResolveRegions(ctx, client, cfg)
+	if err != nil {
+		return nil, []error{fmt.Errorf("리전 조회: %w", err)}
+	}
+	var summaries []*ncp.ResourceSummary
+	var errs []error
+	for _, region := range regions {
+		summary, rerrs := client.ForRegion(region).ListAllResources(ctx)
+		summaries = append(summaries, summary)
+		for _, e := range rerrs {
+			if region != "" {
+				e = fmt.Errorf("[%s] %w", region, e)
+			}
+			errs = append(errs, e)
 		}
 	}
-	summary.AccessControlGroups = acgs
+	return summaries, errs
+}
 
-	var asgs []ncp.AutoScalingGroup
-	for _, s := range summary.AutoScalingGroups {
-		if cfg.AutoScalingGroups.Match(s.AutoScalingGroupName, s.AutoScalingGroupNo) {
-			asgs = append(asgs, s)
+// PlanAccount lists what a cleanup of account would delete, one summary per
+// region: cfg's filters are applied and the resources deleted with their
+// parent (root disks, default ACGs, ...) are dropped.
+func PlanAccount(ctx context.Context, account ncp.RootAccount, cfg *config.Config) ([]*ncp.ResourceSummary, []error) {
+	cfg = cfg.ForAccount(account)
+	summaries, errs := ScanAccount(ctx, account, cfg)
+	for _, s := range summaries {
+		applyFilter(s, cfg)
+		s.DropImplicit()
+	}
+	return summaries, errs
+}
+
+// listRegion logs the (filtered) resource breakdown of the client's region
+// and returns what the filters decided about each resource.
+func listRegion(ctx context.Context, client *ncp.Client, cfg *config.Config, l progress.Logger) []filterDecision {
+	l = l.Phase(progress.PhaseScan)
+	l.Info(1, "리소스 조회 중...")
+	summary, errs := client.ListAllResources(ctx)
+	for _, e := range errs {
+		logResourceErr(l, e)
+	}
+	decisions := applyFilter(summary, cfg)
+	if summary.TotalCount() == 0 {
+		l.Info(1, "리소스 없음")
+		return decisions
+	}
+	l.Emit(progress.Event{Depth: 1, Total: summary.TotalCount(), Message: fmt.Sprintf("총 %d개 리소스:", summary.TotalCount())})
+	for _, rt := range ncp.ResourceTypes() {
+		if n := rt.Count(summary); n > 0 {
+			l.Type(rt.Key()).Emit(progress.Event{Depth: 2, Total: n, Message: fmt.Sprintf("- %s: %d개", rt.Name(), n)})
 		}
 	}
-	summary.AutoScalingGroups = asgs
+	return decisions
+}
 
-	var lcs []ncp.LaunchConfiguration
-	for _, s := range summary.LaunchConfigurations {
-		if cfg.LaunchConfigurations.Match(s.LaunchConfigurationName, s.LaunchConfigurationNo) {
-			lcs = append(lcs, s)
-		}
+// DefaultMaxPasses is how many delete passes cleanupRegion runs at most.
+const DefaultMaxPasses = 3
+
+// MaxPasses returns the configured pass limit, or DefaultMaxPasses.
+func MaxPasses(cfg *config.Config) int {
+	if cfg != nil && cfg.MaxPasses > 0 {
+		return cfg.MaxPasses
 	}
-	summary.LaunchConfigurations = lcs
+	return DefaultMaxPasses
+}
 
-	var clusters []ncp.NksCluster
-	for _, s := range summary.NksClusters {
-		if cfg.NksClusters.Match(s.Name, s.Uuid) {
-			clusters = append(clusters, s)
+// cleanupRegion deletes the (filtered) resources of the client's region in
+// passes: after each pass the region is re-scanned and whatever is left is
+// retried, until nothing is left, a pass deletes nothing or the pass limit is
+// hit. It returns the success count, the fail count (resources left at the
+// end), the leftover resources (nil if ctx was cancelled; including those
+// held back because their tags could not be listed) and, when the last scan
+// could not list every type (see scanForCleanup), an error: the region is
+// then not verified clean, whatever the counts say.
+func cleanupRegion(ctx context.Context, client *ncp.Client, cfg *config.Config, l progress.Logger) (int, int, *ncp.ResourceSummary, error) {
+	summary, held, err := scanForCleanup(ctx, client, cfg, "리소스 조회 중...", l)
+	if n := held.TotalCount() - summary.TotalCount(); n > 0 {
+		l.Phase(progress.PhaseScan).Warn(1, "[경고] 태그를 조회하지 못해 keep 태그 여부를 알 수 없는 리소스 %d개는 삭제하지 않습니다", n)
+	}
+	if summary.TotalCount() == 0 {
+		if err != nil {
+			l.Phase(progress.PhaseScan).Warn(1, "[경고] 조회 오류로 삭제할 리소스를 확인하지 못했습니다")
+			return 0, 0, held, err
 		}
+		l.Phase(progress.PhaseScan).Info(1, "삭제할 리소스 없음")
+		return 0, 0, held, nil
 	}
-	summary.NksClusters = clusters
 
-	var scripts []ncp.InitScript
-	for _, s := range summary.InitScripts {
-		if cfg.InitScripts.Match(s.InitScriptName, s.InitScriptNo) {
-			scripts = append(scripts, s)
+	l = l.Phase(progress.PhasePass)
+	limit := MaxPasses(cfg)
+	success := 0
+	for pass := 1; ; pass++ {
+		msg := fmt.Sprintf("총 %d개 서비스 해지 및 리소스 삭제 시작...", summary.TotalCount())
+		if pass > 1 {
+			msg = fmt.Sprintf("[재시도 %d/%d] 남은 리소스 %d개 삭제 재시도...", pass, limit, summary.TotalCount())
+		}
+		l.Emit(progress.Event{Depth: 1, Total: summary.TotalCount(), Message: msg})
+		s, f := client.CleanupAllResources(ctx, summary, l)
+		l.Emit(progress.Event{Depth: 1, Success: s, Fail: f, Total: s + f, Message: fmt.Sprintf("서비스 해지 및 리소스 삭제 결과: 성공 %d, 실패 %d", s, f)})
+		success += s
+		if ctx.Err() != nil {
+			return success, f, nil, nil
+		}
+
+		prev := summary
+		summary, held, err = scanForCleanup(ctx, client, cfg, "남은 리소스 확인 중...", l)
+		left := summary.TotalCount()
+		switch {
+		case left == 0 && err != nil:
+			l.Warn(1, "[경고] 조회 오류로 남은 리소스를 확인하지 못했습니다")
+			return success, 0, held, err
+		case left == 0:
+			l.OK(1, "남은 리소스 없음")
+			return success, 0, held, nil
+		case pass >= limit:
+			l.Warn(1, "[경고] 최대 삭제 패스(%d회)에 도달했습니다. 남은 리소스 %d개", limit, left)
+			return success, left, held, err
+		case sameResources(prev, summary):
+			l.Warn(1, "[경고] 이번 패스에서 삭제된 리소스가 없어 재시도를 중단합니다. 남은 리소스 %d개", left)
+			return success, left, held, err
 		}
 	}
-	summary.InitScripts = scripts
+}
+
+// scanForCleanup lists the client's region and keeps the resources a cleanup
+// would delete: those matched by cfg's filters, without the implicit ones.
+// held holds the same resources plus those not deleted only because their
+// tags could not be listed (config.RuleTagsUnknown): they are left over, not
+// kept on purpose. The error joins the listing errors other than permission
+// denied and not found (throttling, server or network errors): the types
+// they hit may still hold resources the summary does not show.
+func scanForCleanup(ctx context.Context, client *ncp.Client, cfg *config.Config, header string, l progress.Logger) (summary, held *ncp.ResourceSummary, err error) {
+	l = l.Phase(progress.PhaseScan)
+	l.Info(1, "%s", header)
+	summary, errs := client.ListAllResources(ctx)
+	var failed []error
+	for _, e := range errs {
+		logResourceErr(l, e)
+		if !ncp.IsPermissionDenied(e) && !ncp.IsNotFound(e) {
+			failed = append(failed, e)
+		}
+	}
+	// Filtering replaces the resource slices, so a copy keeps the listing.
+	listed := *summary
+	keep := make(map[string]bool)
+	for _, d := range applyFilter(summary, cfg) {
+		if d.kept || d.rule == config.RuleTagsUnknown {
+			keep[d.typeKey+"\x00"+d.item.ID+"\x00"+d.item.Name] = true
+		}
+	}
+	for _, rt := range ncp.ResourceTypes() {
+		rt.Filter(&listed, func(it ncp.ResourceItem) bool {
+			return keep[rt.Key()+"\x00"+it.ID+"\x00"+it.Name]
+		})
+	}
+	summary.DropImplicit()
+	listed.DropImplicit()
+	return summary, &listed, errors.Join(failed...)
+}
 
-	var keys []ncp.LoginKey
-	for _, s := range summary.LoginKeys {
-		if cfg.LoginKeys.Match(s.KeyName, s.KeyName) {
-			keys = append(keys, s)
+// sameResources reports whether a and b hold the same resources.
+func sameResources(a, b *ncp.ResourceSummary) bool {
+	ka, kb := resourceKeys(a), resourceKeys(b)
+	if len(ka) != len(kb) {
+		return false
+	}
+	for k := range ka {
+		if !kb[k] {
+			return false
 		}
 	}
-	summary.LoginKeys = keys
+	return true
+}
 
-	var placementGroups []ncp.PlacementGroup
-	for _, s := range summary.PlacementGroups {
-		if cfg.PlacementGroups.Match(s.PlacementGroupName, s.PlacementGroupNo) {
-			placementGroups = append(placementGroups, s)
+func resourceKeys(s *ncp.ResourceSummary) map[string]bool {
+	keys := make(map[string]bool)
+	for name, items := range s.Items() {
+		for _, it := range items {
+			keys[name+"\x00"+it.Name+"\x00"+it.ID] = true
 		}
 	}
-	summary.PlacementGroups = placementGroups
+	return keys
+}
 
-	var buckets []ncp.Bucket
-	for _, s := range summary.Buckets {
-		if cfg.Buckets.Match(s.Name, s.Name) {
-			buckets = append(buckets, s)
+// logLeftovers reports the resources left in an account after cleanup, one
+// line per resource.
+func logLeftovers(leftovers []*ncp.ResourceSummary, l progress.Logger) int {
+	total := 0
+	for _, s := range leftovers {
+		total += s.TotalCount()
+	}
+	l = l.Phase(progress.PhaseLeftover)
+	if total == 0 {
+		l.OK(1, "[잔여 리소스] 없음")
+		return 0
+	}
+	l.Emit(progress.Event{Depth: 1, Status: progress.StatusWarn, Total: total, Message: fmt.Sprintf("[잔여 리소스] 삭제되지 않은 리소스 %d개:", total)})
+	for _, s := range leftovers {
+		for _, rt := range ncp.ResourceTypes() {
+			for _, it := range rt.Items(s) {
+				line := "- "
+				if it.Region != "" {
+					line += "[" + it.Region + "] "
+				}
+				line += rt.Name() + ": " + it.Name
+				if it.ID != "" && it.ID != it.Name {
+					line += " (" + it.ID + ")"
+				}
+				if it.TagsUnknown {
+					line += " - 태그 조회 실패로 삭제하지 않음"
+				}
+				l.Region(it.Region).Resource(rt.Key(), it.ID, it.Name).Warn(2, "%s", line)
+			}
 		}
 	}
-	summary.Buckets = buckets
+	return total
+}
 
-	var apigw []ncp.ApiGatewayProduct
-	for _, s := range summary.ApiGatewayProducts {
-		if cfg.ApiGatewayProducts.Match(s.ProductName, s.ProductId) {
-			apigw = append(apigw, s)
+// filterDecision is what cfg's filter decided about one resource.
+type filterDecision struct {
+	typeKey string
+	item    ncp.ResourceItem
+	kept    bool
+	rule    string // the deciding rule, see config.ResourceFilter.Evaluate
+}
+
+// applyFilter drops the resources that cfg's per-type filters (cfg may be
+// nil) do not keep and returns the decision about every resource. All
+// resources are evaluated before any is dropped, so conditions on the parent
+// VPC or subnet see its name even when the VPC itself is filtered out.
+func applyFilter(summary *ncp.ResourceSummary, cfg *config.Config) []filterDecision {
+	var decisions []filterDecision
+	kept := make(map[string]bool)
+	for _, rt := range ncp.ResourceTypes() {
+		f := cfg.Filter(rt.Key())
+		for _, it := range rt.Items(summary) {
+			ok, rule := f.Evaluate(it)
+			decisions = append(decisions, filterDecision{typeKey: rt.Key(), item: it, kept: ok, rule: rule})
+			if ok {
+				kept[rt.Key()+"\x00"+it.ID+"\x00"+it.Name] = true
+			}
 		}
 	}
-	summary.ApiGatewayProducts = apigw
+	for _, rt := range ncp.ResourceTypes() {
+		rt.Filter(summary, func(it ncp.ResourceItem) bool {
+			return kept[rt.Key()+"\x00"+it.ID+"\x00"+it.Name]
+		})
+	}
+	return decisions
 }
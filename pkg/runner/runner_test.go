@@ -0,0 +1,289 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"ncp-nuke/pkg/config"
+	"ncp-nuke/pkg/journal"
+	"ncp-nuke/pkg/ncp"
+	"ncp-nuke/pkg/ncp/ncptest"
+	"ncp-nuke/pkg/progress"
+)
+
+var discard = progress.SinkFunc(func(progress.Event) {})
+
+// testAccount starts a fake NCP server with one empty account and returns
+// its state, the root account and a config pointing at the server.
+func testAccount(t *testing.T) (*ncptest.Server, *ncptest.Account, ncp.RootAccount, *config.Config) {
+	t.Helper()
+	srv := ncptest.NewServer()
+	t.Cleanup(srv.Close)
+	ak := "AK-" + t.Name()
+	acct := srv.AddAccount(ak, "SK")
+	cfg := &config.Config{Endpoints: map[string]string{}, API: config.APIConfig{RateLimit: 1000, RateBurst: 1000}}
+	for svc, u := range srv.Endpoints() {
+		cfg.Endpoints[string(svc)] = u
+	}
+	return srv, acct, ncp.RootAccount{AccountName: "Student-01", AccessKey: ak, SecretKey: "SK"}, cfg
+}
+
+// seedServer seeds a server with a root disk, a data disk and a public IP.
+func seedServer(acct *ncptest.Account, no string) {
+	acct.Seed(ncptest.Servers, ncp.ServerInstance{ServerInstanceNo: no, ServerName: "web-" + no, ServerInstanceStatus: ncp.CommonCode{Code: "RUN"}})
+	acct.Seed(ncptest.BlockStorages,
+		ncp.BlockStorageInstance{BlockStorageInstanceNo: no + "-root", BlockStorageName: "root", ServerInstanceNo: no, BlockStorageDiskDetailType: ncp.CommonCode{Code: "BASIC"}},
+		ncp.BlockStorageInstance{BlockStorageInstanceNo: no + "-data", BlockStorageName: "data", ServerInstanceNo: no},
+	)
+	acct.Seed(ncptest.PublicIps, ncp.PublicIpInstance{PublicIpInstanceNo: no + "-ip", ServerInstanceNo: no})
+}
+
+func nuke(ctx context.Context, acc ncp.RootAccount, cfg *config.Config) Result {
+	return Process(ctx, []ncp.RootAccount{acc}, map[int]bool{0: true}, "nuke", "", false, cfg, nil, discard)
+}
+
+func TestProcessNuke(t *testing.T) {
+	_, acct, acc, cfg := testAccount(t)
+	seedServer(acct, "100")
+	acct.Seed(ncptest.LoginKeys, ncp.LoginKey{KeyName: "key"})
+
+	res := nuke(context.Background(), acc, cfg)
+	if !res.Clean() {
+		t.Errorf("result %+v, want clean", res)
+	}
+	if res.CleanupSuccess != 4 {
+		t.Errorf("%d deleted, want 4 (server, data disk, IP, login key)", res.CleanupSuccess)
+	}
+	if n := acct.Total(); n != 0 {
+		t.Errorf("%d resources left", n)
+	}
+}
+
+func TestProcessKeepTag(t *testing.T) {
+	_, acct, acc, cfg := testAccount(t)
+	seedServer(acct, "100")
+	seedServer(acct, "200")
+	acct.Seed(ncptest.InstanceTags, ncp.InstanceTag{InstanceNo: "100", TagKey: "ncp-nuke:keep", TagValue: "true"})
+
+	res := nuke(context.Background(), acc, cfg)
+	if !res.Clean() {
+		t.Errorf("result %+v, want clean", res)
+	}
+	// The kept server keeps its disks and IP; the other goes entirely.
+	for kind, want := range map[ncptest.Kind]string{
+		ncptest.Servers:       "100",
+		ncptest.BlockStorages: "100-root,100-data",
+		ncptest.PublicIps:     "100-ip",
+	} {
+		if got := strings.Join(acct.IDs(kind), ","); got != want {
+			t.Errorf("%s left: %s, want %s", kind, got, want)
+		}
+	}
+}
+
+func TestProcessList(t *testing.T) {
+	_, acct, acc, cfg := testAccount(t)
+	seedServer(acct, "100")
+	acct.Seed(ncptest.LoginKeys, ncp.LoginKey{KeyName: "key"})
+	cfg.SetFilter("login_keys", config.ResourceFilter{Exclude: []string{"key"}})
+
+	res := Process(context.Background(), []ncp.RootAccount{acc}, map[int]bool{0: true}, "list", "", false, cfg, nil, discard)
+	rules := map[string]string{}
+	for _, r := range res.Resources {
+		rules[r.Type+"/"+r.Name] = r.Rule
+		if r.Type == "login_keys" && r.Kept {
+			t.Error("excluded login key listed as kept")
+		}
+	}
+	if rules["login_keys/key"] != "exclude: key" {
+		t.Errorf("login key rule %q, want exclude: key", rules["login_keys/key"])
+	}
+	if n := acct.Total(); n != 5 {
+		t.Errorf("list changed the account: %d resources, want 5", n)
+	}
+}
+
+func TestProcessAccountGuard(t *testing.T) {
+	_, acct, acc, cfg := testAccount(t)
+	acct.SetMemberNo("12345")
+	acct.Seed(ncptest.LoginKeys, ncp.LoginKey{KeyName: "key"})
+	cfg.AccountGuard = &config.AccountGuard{Deny: []string{"12345"}}
+
+	res := nuke(context.Background(), acc, cfg)
+	if len(res.Failed) != 1 || res.Clean() {
+		t.Errorf("result %+v, want the account refused", res)
+	}
+	if acct.Count(ncptest.LoginKeys) != 1 {
+		t.Error("a denied account was cleaned")
+	}
+
+	cfg.AccountGuard = &config.AccountGuard{Allow: []string{"12345"}}
+	if res := nuke(context.Background(), acc, cfg); !res.Clean() || acct.Count(ncptest.LoginKeys) != 0 {
+		t.Errorf("allowed account: result %+v, %d login keys left", res, acct.Count(ncptest.LoginKeys))
+	}
+}
+
+func TestProcessCancelled(t *testing.T) {
+	_, acct, acc, cfg := testAccount(t)
+	acct.Seed(ncptest.LoginKeys, ncp.LoginKey{KeyName: "key"})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if res := nuke(ctx, acc, cfg); !res.Cancelled || res.Clean() {
+		t.Errorf("result %+v, want cancelled", res)
+	}
+	if acct.Count(ncptest.LoginKeys) != 1 {
+		t.Error("a cancelled run deleted resources")
+	}
+}
+
+// failingTransport fails the requests for action while fail returns true:
+// with a response of status if set, else with a connection error.
+type failingTransport struct {
+	base   http.RoundTripper
+	action string
+	status int
+
+	mu    sync.Mutex
+	calls int
+	fail  func(call int) bool
+}
+
+func (t *failingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if strings.HasSuffix(r.URL.Path, "/"+t.action) {
+		t.mu.Lock()
+		t.calls++
+		fail := t.fail(t.calls)
+		t.mu.Unlock()
+		if fail && t.status != 0 {
+			return &http.Response{StatusCode: t.status, Header: http.Header{}, Body: io.NopCloser(strings.NewReader("{}")), Request: r}, nil
+		}
+		if fail {
+			return nil, errors.New("connection reset")
+		}
+	}
+	return t.base.RoundTrip(r)
+}
+
+// regionClient returns a client of the fake account whose requests go
+// through tr, without retries.
+func regionClient(srv *ncptest.Server, acc ncp.RootAccount, cfg *config.Config, tr *failingTransport) *ncp.Client {
+	tr.base = srv.Client().Transport
+	return NewClient(acc, cfg, ncp.WithHTTPClient(&http.Client{Transport: tr}), ncp.WithRetryPolicy(ncp.RetryPolicy{MaxAttempts: 1}))
+}
+
+func TestCleanupRegionRetries(t *testing.T) {
+	srv, acct, acc, cfg := testAccount(t)
+	acct.Seed(ncptest.LoginKeys, ncp.LoginKey{KeyName: "key"})
+	seedServer(acct, "100")
+	tr := &failingTransport{action: "deleteLoginKeys", fail: func(call int) bool { return call == 1 }}
+
+	success, fail, left, err := cleanupRegion(context.Background(), regionClient(srv, acc, cfg, tr), cfg, progress.NewLogger(discard))
+	if err != nil || fail != 0 || left.TotalCount() != 0 {
+		t.Errorf("fail %d, %d left, err %v, want the second pass to delete the login key", fail, left.TotalCount(), err)
+	}
+	if success != 4 || tr.calls != 2 {
+		t.Errorf("%d deleted in %d login key attempts, want 4 in 2", success, tr.calls)
+	}
+	if n := acct.Total(); n != 0 {
+		t.Errorf("%d resources left", n)
+	}
+}
+
+func TestCleanupRegionStopsWithoutProgress(t *testing.T) {
+	srv, acct, acc, cfg := testAccount(t)
+	acct.Seed(ncptest.LoginKeys, ncp.LoginKey{KeyName: "key"})
+	cfg.MaxPasses = 5
+	tr := &failingTransport{action: "deleteLoginKeys", fail: func(int) bool { return true }}
+
+	_, fail, left, _ := cleanupRegion(context.Background(), regionClient(srv, acc, cfg, tr), cfg, progress.NewLogger(discard))
+	if fail != 1 || left.TotalCount() != 1 {
+		t.Errorf("fail %d, %d left, want the login key left", fail, left.TotalCount())
+	}
+	// The first pass deletes nothing, so no second pass runs.
+	if tr.calls != 1 {
+		t.Errorf("%d attempts, want 1", tr.calls)
+	}
+}
+
+func TestCleanupRegionPassLimit(t *testing.T) {
+	srv, acct, acc, cfg := testAccount(t)
+	acct.Seed(ncptest.LoginKeys, ncp.LoginKey{KeyName: "a"}, ncp.LoginKey{KeyName: "b"}, ncp.LoginKey{KeyName: "c"})
+	cfg.MaxPasses = 2
+	cfg.Workers = 1
+	// Every pass deletes one key and fails the rest.
+	tr := &failingTransport{action: "deleteLoginKeys", fail: func(call int) bool { return call != 1 && call != 3 }}
+
+	_, fail, _, _ := cleanupRegion(context.Background(), regionClient(srv, acc, cfg, tr), cfg, progress.NewLogger(discard))
+	if fail != 1 || acct.Count(ncptest.LoginKeys) != 1 {
+		t.Errorf("fail %d, %d keys left, want 1 and 1 after 2 passes", fail, acct.Count(ncptest.LoginKeys))
+	}
+}
+
+func TestCleanupRegionRescanFails(t *testing.T) {
+	srv, acct, acc, cfg := testAccount(t)
+	acct.Seed(ncptest.LoginKeys, ncp.LoginKey{KeyName: "key"})
+	// The first scan lists VPCs; every later VPC list fails.
+	tr := &failingTransport{action: "getVpcList", fail: func(call int) bool { return call > 1 }}
+
+	_, fail, left, err := cleanupRegion(context.Background(), regionClient(srv, acc, cfg, tr), cfg, progress.NewLogger(discard))
+	if err == nil {
+		t.Errorf("fail %d, %d left, want the region unverified", fail, left.TotalCount())
+	}
+	if acct.Count(ncptest.LoginKeys) != 0 {
+		t.Error("login key not deleted")
+	}
+}
+
+func TestProcessScanFails(t *testing.T) {
+	_, acct, acc, cfg := testAccount(t)
+	acct.Seed(ncptest.LoginKeys, ncp.LoginKey{KeyName: "key"})
+	// Nothing listens here: the VPC types cannot be listed at all.
+	dead := httptest.NewServer(http.NotFoundHandler())
+	dead.Close()
+	cfg.Endpoints[string(ncp.ServiceVPC)] = dead.URL
+	cfg.API.MaxAttempts = 1
+	j, err := journal.Create(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer j.Close()
+
+	res := Process(context.Background(), []ncp.RootAccount{acc}, map[int]bool{0: true}, "nuke", "", false, cfg, j, discard)
+	if res.Clean() || len(res.Failed) != 1 {
+		t.Errorf("result %+v, want the account failed", res)
+	}
+	records, err := journal.Read(j.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if runs := journal.Runs(records); len(runs) != 1 || runs[0].Done {
+		t.Error("unverified account journaled as complete")
+	}
+}
+
+func TestCleanupRegionTagsUnknown(t *testing.T) {
+	srv, acct, acc, cfg := testAccount(t)
+	seedServer(acct, "100")
+	acct.Seed(ncptest.LoginKeys, ncp.LoginKey{KeyName: "key"})
+	// The key may not read tags: the server could carry the keep tag.
+	tr := &failingTransport{action: "getInstanceTagList", status: http.StatusForbidden, fail: func(int) bool { return true }}
+
+	_, fail, left, err := cleanupRegion(context.Background(), regionClient(srv, acc, cfg, tr), cfg, progress.NewLogger(discard))
+	if err != nil || fail != 0 {
+		t.Errorf("fail %d, err %v, want no failed deletion", fail, err)
+	}
+	if acct.Count(ncptest.LoginKeys) != 0 || acct.Count(ncptest.Servers) != 1 {
+		t.Errorf("%d login keys, %d servers left, want only the server", acct.Count(ncptest.LoginKeys), acct.Count(ncptest.Servers))
+	}
+	// The server, its data disk and IP are left over, not silently kept.
+	if n := left.TotalCount(); n != 3 {
+		t.Errorf("%d leftovers, want 3", n)
+	}
+}
@@ -7,7 +7,9 @@ import (
 
 	"ncp-nuke/pkg/config"
 	"ncp-nuke/pkg/excel"
+	"ncp-nuke/pkg/journal"
 	"ncp-nuke/pkg/ncp"
+	"ncp-nuke/pkg/progress"
 	"ncp-nuke/pkg/runner"
 
 	"github.com/charmbracelet/bubbles/table"
@@ -54,11 +56,13 @@ type model struct {
 	cfg            *config.Config
 	logs           *strings.Builder
 	logChan        chan string
+	cancel         context.CancelFunc // cancels the running job; nil when idle
 	windowWidth    int
 	windowHeight   int
 }
 
-func Start(filePath, configPath, accountFilter string) error {
+// Start runs the TUI with the command-line overrides applied to the config.
+func Start(filePath, configPath, accountFilter string, overrides config.Overrides) error {
 	accounts, err := excel.ReadAccounts(filePath)
 	if err != nil {
 		return err
@@ -85,6 +89,13 @@ func Start(filePath, configPath, accountFilter string) error {
 			return err
 		}
 	}
+	cfg = overrides.Apply(cfg)
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	fmt.Println("계정 식별 정보(회원 번호) 확인 중...")
+	runner.ResolveIdentities(context.Background(), accounts, cfg)
 
 	m := initialModel(accounts, cfg)
 	if _, err := tea.NewProgram(m, tea.WithAltScreen()).Run(); err != nil {
@@ -97,14 +108,13 @@ func initialModel(accounts []ncp.RootAccount, cfg *config.Config) model {
 	columns := []table.Column{
 		{Title: "선택", Width: 6},
 		{Title: "Account Name", Width: 20},
+		{Title: "회원 번호", Width: 18},
 		{Title: "IAM Username", Width: 15},
 		{Title: "Access Key", Width: 25},
+		{Title: "Site", Width: 8},
 	}
 
-	rows := []table.Row{}
-	for _, acc := range accounts {
-		rows = append(rows, table.Row{"[ ]", acc.AccountName, acc.IamUsername, acc.AccessKey})
-	}
+	rows := updateRows(accounts, map[int]bool{}, cfg)
 
 	t := table.New(
 		table.WithColumns(columns),
@@ -181,6 +191,16 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "ctrl+c":
+			// First ctrl+c while running cancels the job (API calls and waits stop
+			// immediately); a second one quits.
+			if m.state == stateRunning && m.cancel != nil {
+				m.cancel()
+				m.cancel = nil
+				m.logs.WriteString("\n[취소 요청] 진행 중인 작업을 중단합니다... (다시 Ctrl+C: 강제 종료)\n")
+				m.viewport.SetContent(m.logs.String())
+				m.viewport.GotoBottom()
+				return m, nil
+			}
 			return m, tea.Quit
 		case "q":
 			if m.state != stateRunning && m.state != statePasswordInput && m.state != stateTypingConfirm {
@@ -197,7 +217,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				} else {
 					m.selected[idx] = true
 				}
-				m.table.SetRows(updateRows(m.accounts, m.selected))
+				m.table.SetRows(updateRows(m.accounts, m.selected, m.cfg))
 			} else if msg.String() == "enter" {
 				if len(m.selected) > 0 {
 					m.state = stateSelectAction
@@ -271,14 +291,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.confirmErr = false
 					return m, m.confirmInput.Cursor.BlinkCmd()
 				}
-				m.state = stateRunning
-				go func() {
-					runner.Process(context.Background(), m.accounts, m.selected, m.action, m.globalPassword, m.cleanup, m.cfg, func(s string) {
-						m.logChan <- s
-					})
-					close(m.logChan)
-				}()
-				return m, waitForLog(m.logChan)
+				return m, m.startRun()
 
 			case "c", "C":
 				if m.action == "deactivate" {
@@ -292,14 +305,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			switch msg.String() {
 			case "enter":
 				if m.confirmInput.Value() == confirmPhrase {
-					m.state = stateRunning
-					go func() {
-						runner.Process(context.Background(), m.accounts, m.selected, m.action, m.globalPassword, m.cleanup, m.cfg, func(s string) {
-							m.logChan <- s
-						})
-						close(m.logChan)
-					}()
-					return m, waitForLog(m.logChan)
+					return m, m.startRun()
 				}
 				m.confirmErr = true
 			case "esc":
@@ -327,6 +333,10 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, waitForLog(m.logChan)
 
 	case doneMsg:
+		if m.cancel != nil {
+			m.cancel()
+			m.cancel = nil
+		}
 		m.state = stateDone
 		m.logs.WriteString("\n=== 모든 작업 완료 ===\n[Enter]를 눌러 종료하거나 [q]로 나가세요.")
 		m.viewport.SetContent(m.logs.String())
@@ -347,7 +357,32 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
-func updateRows(accounts []ncp.RootAccount, selected map[int]bool) []table.Row {
+// startRun launches the selected action in the background with a cancellable
+// context and switches to the log view.
+func (m *model) startRun() tea.Cmd {
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+	m.state = stateRunning
+	accounts, selected, action, password, cleanup, cfg, logChan := m.accounts, m.selected, m.action, m.globalPassword, m.cleanup, m.cfg, m.logChan
+	go func() {
+		logFn := func(s string) { logChan <- s }
+		var j *journal.Writer
+		if action != "list" {
+			var err error
+			if j, err = runner.NewJournal(cfg); err != nil {
+				logFn(fmt.Sprintf("[경고] %v (저널 없이 진행합니다)", err))
+			} else if j != nil {
+				logFn(fmt.Sprintf("작업 저널: %s (중단되면 ncp-nuke resume 으로 재개할 수 있습니다)", j.Path()))
+			}
+		}
+		runner.Process(ctx, accounts, selected, action, password, cleanup, cfg, j, progress.TextSink(logFn))
+		j.Close()
+		close(logChan)
+	}()
+	return waitForLog(logChan)
+}
+
+func updateRows(accounts []ncp.RootAccount, selected map[int]bool, cfg *config.Config) []table.Row {
 	rows := []table.Row{}
 	for i, acc := range accounts {
 		mark := "[ ]"
@@ -355,11 +390,24 @@ func updateRows(accounts []ncp.RootAccount, selected map[int]bool) []table.Row {
 			mark = "[x]"
 		}
 
-		rows = append(rows, table.Row{mark, acc.AccountName, acc.IamUsername, acc.AccessKey})
+		rows = append(rows, table.Row{mark, acc.AccountName, identityLabel(acc, cfg), acc.IamUsername, acc.AccessKey, string(runner.SiteOf(acc, cfg))})
 	}
 	return rows
 }
 
+// identityLabel shows the member number resolved for acc and whether the
+// account guard of cfg refuses the account.
+func identityLabel(acc ncp.RootAccount, cfg *config.Config) string {
+	label := acc.MemberNo
+	if label == "" {
+		label = "확인 실패"
+	}
+	if config.CheckAccount(cfg, acc.MemberNo) != nil {
+		label += " (차단)"
+	}
+	return label
+}
+
 func (m model) View() string {
 	if m.windowWidth == 0 {
 		return "Loading..."
@@ -425,7 +473,7 @@ func (m model) View() string {
 		count := 0
 		for i := range m.selected {
 			if count < 5 {
-				targets += fmt.Sprintf("- %s\n", m.accounts[i].AccountName)
+				targets += fmt.Sprintf("- %s (회원 번호: %s)\n", m.accounts[i].AccountName, identityLabel(m.accounts[i], m.cfg))
 			}
 			count++
 		}
@@ -433,6 +481,11 @@ func (m model) View() string {
 			targets += fmt.Sprintf("... 외 %d개\n", count-5)
 		}
 
+		regionInfo := ""
+		if m.action != "activate" && m.cfg != nil && len(m.cfg.Regions) > 0 {
+			regionInfo = fmt.Sprintf("\n리전: %s\n", strings.Join(m.cfg.Regions, ", "))
+		}
+
 		options := ""
 		if m.action == "deactivate" {
 			cleanupStatus := "[ ] Cleanup (서비스 해지 및 리소스 삭제)"
@@ -449,12 +502,12 @@ func (m model) View() string {
 %s
 
 작업: %s
-
+%s
 선택된 계정:
 %s
 %s
 진행하시겠습니까? (y: 시작, b: 뒤로, q: 종료)
-`, titleStyle.Render("작업 확인"), actionLabel, targets, options)
+`, titleStyle.Render("작업 확인"), actionLabel, regionInfo, targets, options)
 		return baseStyle.Render(content)
 
 	case stateTypingConfirm:
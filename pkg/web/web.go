@@ -3,6 +3,7 @@
 package web
 
 import (
+	"context"
 	"embed"
 	"encoding/json"
 	"fmt"
@@ -18,6 +19,8 @@ import (
 	"ncp-nuke/pkg/config"
 	"ncp-nuke/pkg/excel"
 	"ncp-nuke/pkg/ncp"
+	"ncp-nuke/pkg/plan"
+	"ncp-nuke/pkg/progress"
 	"ncp-nuke/pkg/runner"
 	"ncp-nuke/pkg/version"
 
@@ -36,6 +39,10 @@ type Server struct {
 	cfg      *config.Config
 	Desktop  bool       // when true, file open/save use native OS dialogs
 	mu       sync.Mutex // serialize destructive runs and account mutations
+
+	// identities caches the member number resolved for each access key pair
+	// (by Fingerprint); "" when it could not be resolved.
+	identities map[string]string
 }
 
 // NewServer optionally preloads accounts from an Excel file. filePath may be
@@ -60,6 +67,24 @@ func NewServer(filePath, configPath string) (*Server, error) {
 	return &Server{accounts: accounts, filePath: filePath, cfg: cfg}, nil
 }
 
+// SetOverrides applies the command-line settings to the server config.
+func (s *Server) SetOverrides(o config.Overrides) {
+	s.cfg = o.Apply(s.cfg)
+}
+
+// requestConfig returns the server config with the per-request settings o
+// applied. The server's own config is never modified.
+func (s *Server) requestConfig(o config.Overrides) *config.Config {
+	if o.IsZero() {
+		return s.cfg
+	}
+	var cfg config.Config
+	if s.cfg != nil {
+		cfg = *s.cfg
+	}
+	return o.Apply(&cfg)
+}
+
 func (s *Server) Handler() http.Handler {
 	mux := http.NewServeMux()
 
@@ -84,6 +109,7 @@ func (s *Server) Handler() http.Handler {
 	mux.HandleFunc("/api/open-url", s.handleOpenURL)
 	mux.HandleFunc("/api/scan", s.handleScan)
 	mux.HandleFunc("/api/execute", s.handleExecute)
+	mux.HandleFunc("/api/plan/load", s.handlePlanLoad)
 	return mux
 }
 
@@ -92,6 +118,12 @@ type accountDTO struct {
 	AccountName string `json:"accountName"`
 	IamUsername string `json:"iamUsername"`
 	AccessKey   string `json:"accessKey"`
+	Site        string `json:"site"` // effective cloud site (public, fin, gov)
+
+	// MemberNo is the NCP member number resolved from the keys ("" =
+	// unknown); Blocked is why the account guard refuses the account.
+	MemberNo string `json:"memberNo"`
+	Blocked  string `json:"blocked,omitempty"`
 }
 
 // handleUpload accepts an uploaded accounts .xlsx, parses it, and replaces the
@@ -141,14 +173,18 @@ func (s *Server) handleUpload(w http.ResponseWriter, r *http.Request) {
 	s.filePath = tmp.Name()
 	s.mu.Unlock()
 
-	s.listAccounts(w)
+	s.listAccounts(w, r)
 }
 
 // handleEnv tells the frontend whether it is running inside the desktop app
 // (so it can use native file dialogs instead of browser upload/download).
 func (s *Server) handleEnv(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]any{"desktop": s.Desktop, "version": version.Version})
+	var regions []string
+	if s.cfg != nil {
+		regions = s.cfg.Regions
+	}
+	json.NewEncoder(w).Encode(map[string]any{"desktop": s.Desktop, "version": version.Version, "regions": regions})
 }
 
 type ghRelease struct {
@@ -377,7 +413,7 @@ func (s *Server) handlePickAccounts(w http.ResponseWriter, r *http.Request) {
 	s.accounts = accounts
 	s.filePath = path // their real file — "계정 추가" appends back here
 	s.mu.Unlock()
-	s.listAccounts(w)
+	s.listAccounts(w, r)
 }
 
 // handleSaveTemplate (desktop) writes the template to a folder the user picks
@@ -423,7 +459,7 @@ func (s *Server) handleTemplate(w http.ResponseWriter, r *http.Request) {
 func (s *Server) handleAccounts(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodGet:
-		s.listAccounts(w)
+		s.listAccounts(w, r)
 	case http.MethodPost:
 		s.addAccount(w, r)
 	default:
@@ -431,28 +467,76 @@ func (s *Server) handleAccounts(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func (s *Server) listAccounts(w http.ResponseWriter) {
+func (s *Server) listAccounts(w http.ResponseWriter, r *http.Request) {
+	s.resolveIdentities(r.Context())
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	out := make([]accountDTO, 0, len(s.accounts))
 	for i, a := range s.accounts {
-		out = append(out, accountDTO{
-			Index:       i,
-			AccountName: a.AccountName,
-			IamUsername: a.IamUsername,
-			AccessKey:   maskKey(a.AccessKey),
-		})
+		out = append(out, s.accountDTO(i, a))
 	}
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(out)
 }
 
+func (s *Server) accountDTO(i int, a ncp.RootAccount) accountDTO {
+	dto := accountDTO{
+		Index:       i,
+		AccountName: a.AccountName,
+		IamUsername: a.IamUsername,
+		AccessKey:   maskKey(a.AccessKey),
+		Site:        string(runner.SiteOf(a, s.cfg)),
+		MemberNo:    a.MemberNo,
+	}
+	if err := config.CheckAccount(s.cfg, a.MemberNo); err != nil {
+		dto.Blocked = err.Error()
+	}
+	return dto
+}
+
+// resolveIdentities fills in the member number of every account when the
+// config has an account guard, asking the API only for key pairs not resolved
+// before. The lookups run without s.mu held; failed ones are not cached, so
+// the next request tries again.
+func (s *Server) resolveIdentities(ctx context.Context) {
+	if !config.Guarded(s.cfg) {
+		return
+	}
+	s.mu.Lock()
+	if s.identities == nil {
+		s.identities = make(map[string]string)
+	}
+	var todo []ncp.RootAccount
+	for _, a := range s.accounts {
+		if _, ok := s.identities[a.Fingerprint()]; !ok {
+			a.MemberNo = ""
+			todo = append(todo, a)
+		}
+	}
+	s.mu.Unlock()
+
+	runner.ResolveIdentities(ctx, todo, s.cfg)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, a := range todo {
+		if a.MemberNo != "" {
+			s.identities[a.Fingerprint()] = a.MemberNo
+		}
+	}
+	for i := range s.accounts {
+		s.accounts[i].MemberNo = s.identities[s.accounts[i].Fingerprint()]
+	}
+}
+
 type newAccountRequest struct {
 	AccountName string `json:"accountName"`
 	AccessKey   string `json:"accessKey"`
 	SecretKey   string `json:"secretKey"`
 	IamUsername string `json:"iamUsername"`
 	Password    string `json:"password"`
+	Site        string `json:"site"`     // empty = default site
+	Platform    string `json:"platform"` // empty = default platform
 }
 
 // addAccount appends a new account to both the in-memory list and the Excel file.
@@ -477,28 +561,46 @@ func (s *Server) addAccount(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "IAM Username은 필수입니다", http.StatusBadRequest)
 		return
 	}
+	if req.Site != "" {
+		site, err := ncp.ParseSite(req.Site)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		acc.Site = site
+	}
+	if req.Platform != "" {
+		platform, err := ncp.ParsePlatform(req.Platform)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		acc.Platform = platform
+	}
 
 	s.mu.Lock()
-	defer s.mu.Unlock()
-
 	if acc.AccountName == "" {
 		acc.AccountName = fmt.Sprintf("Account-%d", len(s.accounts)+1)
 	}
 
 	// Persist to the Excel file first; only update memory if the write succeeds.
 	if err := excel.AppendAccount(s.filePath, acc); err != nil {
+		s.mu.Unlock()
 		http.Error(w, "엑셀 저장 실패: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 	s.accounts = append(s.accounts, acc)
+	i := len(s.accounts) - 1
+	s.mu.Unlock()
 
+	s.resolveIdentities(r.Context())
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if i < len(s.accounts) && s.accounts[i].Fingerprint() == acc.Fingerprint() {
+		acc = s.accounts[i]
+	}
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(accountDTO{
-		Index:       len(s.accounts) - 1,
-		AccountName: acc.AccountName,
-		IamUsername: acc.IamUsername,
-		AccessKey:   maskKey(acc.AccessKey),
-	})
+	json.NewEncoder(w).Encode(s.accountDTO(i, acc))
 }
 
 func (s *Server) selectedMap(idxs []int) map[int]bool {
@@ -514,7 +616,9 @@ func (s *Server) selectedMap(idxs []int) map[int]bool {
 // --- Scan: list resources for the selected accounts ---
 
 type scanRequest struct {
-	Selected []int `json:"selected"`
+	Selected []int    `json:"selected"`
+	Regions  []string `json:"regions"` // overrides the configured regions; ["all"] = every region
+	Vpcs     []string `json:"vpcs"`    // overrides the configured VPC scope
 }
 
 type resourceCountDTO struct {
@@ -523,9 +627,17 @@ type resourceCountDTO struct {
 }
 
 type itemDTO struct {
-	Account string `json:"account"`
-	Name    string `json:"name"`
-	ID      string `json:"id"`
+	Account string            `json:"account"`
+	Region  string            `json:"region"`
+	Name    string            `json:"name"`
+	ID      string            `json:"id"`
+	Tags    map[string]string `json:"tags,omitempty"`
+
+	// Protected marks resources with the keep tag; they are never deleted.
+	Protected bool `json:"protected,omitempty"`
+	// Excluded is the age limit (older_than / newer_than) that keeps the
+	// resource from being deleted, if any.
+	Excluded string `json:"excluded,omitempty"`
 }
 
 type scanResponse struct {
@@ -558,19 +670,21 @@ func (s *Server) handleScan(w http.ResponseWriter, r *http.Request) {
 
 	// Collect the accounts to scan (preserving order for deterministic output).
 	type acctScan struct {
-		name    string
-		summary *ncp.ResourceSummary
-		errs    []error
+		name      string
+		cfg       *config.Config         // resolved for the account
+		summaries []*ncp.ResourceSummary // one per region
+		errs      []error
 	}
+	cfg := s.requestConfig(config.Overrides{Regions: req.Regions, VpcScope: req.Vpcs})
 	var jobs []*acctScan
 	for i, acc := range s.accounts {
 		if selected[i] {
-			jobs = append(jobs, &acctScan{name: acc.AccountName})
+			jobs = append(jobs, &acctScan{name: acc.AccountName, cfg: cfg.ForAccount(acc)})
 		}
 	}
 
 	// Scan each account in parallel — each makes its own (sequential) set of
-	// resource list calls with an independent client.
+	// resource list calls per region with an independent client.
 	var wg sync.WaitGroup
 	idx := 0
 	for i, acc := range s.accounts {
@@ -582,8 +696,7 @@ func (s *Server) handleScan(w http.ResponseWriter, r *http.Request) {
 		wg.Add(1)
 		go func(a ncp.RootAccount, j *acctScan) {
 			defer wg.Done()
-			client := ncp.NewClient(a.AccessKey, a.SecretKey)
-			j.summary, j.errs = client.ListAllResources()
+			j.summaries, j.errs = runner.ScanAccount(r.Context(), a, cfg)
 		}(acc, job)
 	}
 	wg.Wait()
@@ -599,18 +712,27 @@ func (s *Server) handleScan(w http.ResponseWriter, r *http.Request) {
 		for _, e := range j.errs {
 			warnings = append(warnings, friendlyScanErr(j.name, e))
 		}
-		if j.summary == nil {
-			continue
-		}
-		for _, bc := range j.summary.Breakdown() {
-			if _, ok := counts[bc.Name]; !ok {
-				order = append(order, bc.Name)
-			}
-			counts[bc.Name] += bc.Count
-		}
-		for typeName, items := range j.summary.Items() {
-			for _, it := range items {
-				details[typeName] = append(details[typeName], itemDTO{Account: j.name, Name: it.Name, ID: it.ID})
+		for _, summary := range j.summaries {
+			// Only the resources inside the VPC scope (if any) are shown.
+			for _, rt := range ncp.ResourceTypes() {
+				age := ageFilter(j.cfg, rt.Key())
+				for _, it := range rt.Items(summary) {
+					if !config.InVpcScope(j.cfg, it) {
+						continue
+					}
+					if _, ok := counts[rt.Name()]; !ok {
+						order = append(order, rt.Name())
+					}
+					counts[rt.Name()]++
+					dto := itemDTO{
+						Account: j.name, Region: it.Region, Name: it.Name, ID: it.ID,
+						Tags: it.Tags, Protected: config.Protected(j.cfg, it),
+					}
+					if ok, rule := age.Evaluate(it); !ok {
+						dto.Excluded = rule
+					}
+					details[rt.Name()] = append(details[rt.Name()], dto)
+				}
 			}
 		}
 	}
@@ -623,13 +745,17 @@ func (s *Server) handleScan(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(resp)
 }
 
+// ageFilter returns just the age limits of cfg's filter of the resource type
+// with the given config key: the part of the filters the web console applies
+// to the resources picked from a scan.
+func ageFilter(cfg *config.Config, key string) config.ResourceFilter {
+	f := cfg.Filter(key)
+	return config.ResourceFilter{OlderThan: f.OlderThan, NewerThan: f.NewerThan}
+}
+
 func friendlyScanErr(account string, e error) string {
-	msg := e.Error()
-	for _, sig := range []string{"HTTP 403", "StatusCode: 403", "AccessDenied", "InvalidAccessKeyId",
-		"HTTP 404", "URL not found", "Not Found Exception"} {
-		if strings.Contains(msg, sig) {
-			return fmt.Sprintf("[%s] 권한 없음/미지원: %v", account, e)
-		}
+	if ncp.IsPermissionDenied(e) || ncp.IsNotFound(e) {
+		return fmt.Sprintf("[%s] 권한 없음/미지원: %v", account, e)
 	}
 	return fmt.Sprintf("[%s] %v", account, e)
 }
@@ -644,7 +770,11 @@ type executeRequest struct {
 	// identifiers (id, or name) to delete — so only the resources shown in the
 	// scan are removed, not anything created since.
 	Targets map[string][]string `json:"targets"`
-	Confirm string              `json:"confirm"`
+	Regions []string            `json:"regions"` // regions that were scanned
+	// Plan, when set, replaces Targets and Selected: exactly the resources
+	// of a plan file (see /api/plan/load) are deleted.
+	Plan    json.RawMessage `json:"plan,omitempty"`
+	Confirm string          `json:"confirm"`
 }
 
 func (s *Server) handleExecute(w http.ResponseWriter, r *http.Request) {
@@ -657,6 +787,19 @@ func (s *Server) handleExecute(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "잘못된 요청: "+err.Error(), http.StatusBadRequest)
 		return
 	}
+	var p *plan.Plan
+	if len(req.Plan) > 0 {
+		s.mu.Lock()
+		var err error
+		if p, err = s.checkPlan(req.Plan); err == nil {
+			req.Selected = s.planSelection(p)
+		}
+		s.mu.Unlock()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
 	if len(req.Selected) == 0 {
 		http.Error(w, "선택된 계정이 없습니다", http.StatusBadRequest)
 		return
@@ -670,11 +813,12 @@ func (s *Server) handleExecute(w http.ResponseWriter, r *http.Request) {
 	if req.SubAction == "" {
 		req.SubAction = "none"
 	}
-	if len(req.Targets) == 0 && req.SubAction == "none" {
+	deleting := len(req.Targets) > 0 || p != nil
+	if !deleting && req.SubAction == "none" {
 		http.Error(w, "수행할 작업이 없습니다 (삭제할 리소스 또는 서브계정 작업을 선택하세요)", http.StatusBadRequest)
 		return
 	}
-	if len(req.Targets) > 0 && req.Confirm != confirmPhrase {
+	if deleting && req.Confirm != confirmPhrase {
 		http.Error(w, fmt.Sprintf("삭제 확인 문구가 일치하지 않습니다. \"%s\" 를 정확히 입력하세요.", confirmPhrase), http.StatusBadRequest)
 		return
 	}
@@ -684,22 +828,57 @@ func (s *Server) handleExecute(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "스트리밍 미지원", http.StatusInternalServerError)
 		return
 	}
+	j, err := runner.NewJournal(s.cfg)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer j.Close()
 	w.Header().Set("Content-Type", "text/event-stream")
 	w.Header().Set("Cache-Control", "no-cache")
 	w.Header().Set("Connection", "keep-alive")
 
+	// Resolve before taking the lock, so a slow Billing API never blocks
+	// other requests.
+	s.resolveIdentities(r.Context())
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	selected := s.selectedMap(req.Selected)
-	cfg := buildDeleteConfig(req.Targets)
+	targets := buildDeleteConfig(req.Targets)
+	base := s.requestConfig(config.Overrides{Regions: req.Regions})
+	// The account's settings with the scanned targets as its filters. The
+	// scan only listed resources inside the VPC scope; the age limits are
+	// kept, so a resource the scan marked as excluded is never deleted even
+	// if it is sent as a target.
+	deleteConfig := func(acc ncp.RootAccount) *config.Config {
+		var cfg config.Config
+		b := base.ForAccount(acc)
+		if b != nil {
+			cfg = *b
+		}
+		cfg.Filters = make(map[string]config.ResourceFilter, len(targets.Filters))
+		for key, f := range targets.Filters {
+			age := ageFilter(b, key)
+			f.OlderThan, f.NewerThan = age.OlderThan, age.NewerThan
+			cfg.Filters[key] = f
+		}
+		cfg.VpcScope = nil
+		return &cfg
+	}
+	if p != nil {
+		deleteConfig = func(acc ncp.RootAccount) *config.Config {
+			pa, _ := p.Account(acc.AccountName)
+			return pa.Config(s.cfg.ForAccount(acc))
+		}
+	}
 
 	// Serialize SSE writes; each account runs in its own goroutine so deletion
 	// happens in parallel across accounts. Events are tagged with the account so
 	// the UI routes each line to that account's card.
 	var emitMu sync.Mutex
 	emit := func(ev progressEvent) {
-		if ev.Text == "" {
+		if ev.Message == "" {
 			return
 		}
 		emitMu.Lock()
@@ -709,28 +888,35 @@ func (s *Server) handleExecute(w http.ResponseWriter, r *http.Request) {
 		flusher.Flush()
 	}
 
+	if j != nil {
+		emit(newProgressEvent(progress.Event{
+			Time: time.Now(), Phase: progress.PhaseRun, Status: progress.StatusInfo,
+			Message: "작업 저널: " + j.Path(),
+		}))
+	}
+
+	// Check the account guard once per account before any work starts, so
+	// no later config rebuild can drop it.
+	send := progress.SinkFunc(func(e progress.Event) { emit(newProgressEvent(e)) })
 	var wg sync.WaitGroup
 	for i := range s.accounts {
 		if !selected[i] {
 			continue
 		}
 		acc := s.accounts[i]
+		if err := config.CheckAccount(s.cfg.ForAccount(acc), acc.MemberNo); err != nil {
+			progress.NewLogger(send).Account(acc.AccountName).Phase(progress.PhaseAccount).Fail(0, err, "[차단] %v", err)
+			continue
+		}
 		one := map[int]bool{i: true}
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			cur := "" // per-account resource grouping state
-			send := func(line string) {
-				ev := classifyLine(line, &cur)
-				ev.Account = acc.AccountName
-				emit(ev)
-			}
 			if req.SubAction == "activate" || req.SubAction == "deactivate" {
-				runner.Process(r.Context(), s.accounts, one, req.SubAction, req.Password, false, nil, send)
-				cur = ""
+				runner.Process(r.Context(), s.accounts, one, req.SubAction, req.Password, false, s.cfg, j, send)
 			}
-			if len(req.Targets) > 0 {
-				runner.Process(r.Context(), s.accounts, one, "nuke", "", false, cfg, send)
+			if deleting {
+				runner.Process(r.Context(), s.accounts, one, "nuke", "", false, deleteConfig(acc), j, send)
 			}
 		}()
 	}
@@ -740,171 +926,127 @@ func (s *Server) handleExecute(w http.ResponseWriter, r *http.Request) {
 	flusher.Flush()
 }
 
-// buildDeleteConfig returns a config that deletes ONLY the scanned resources:
-// for each selected type, the filter includes just the given identifiers (id or
-// name); every other type is disabled.
-func buildDeleteConfig(targets map[string][]string) *config.Config {
-	disabled := func(name string) config.ResourceFilter {
-		ids, ok := targets[name]
-		if !ok {
-			off := false
-			return config.ResourceFilter{Enabled: &off}
+// checkPlan parses a plan and checks it against the loaded accounts.
+func (s *Server) checkPlan(data []byte) (*plan.Plan, error) {
+	p, err := plan.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.Check(s.accounts, plan.DefaultMaxAge); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// planSelection returns the indexes of the loaded accounts in p.
+func (s *Server) planSelection(p *plan.Plan) []int {
+	var idxs []int
+	for i, acc := range s.accounts {
+		if _, ok := p.Account(acc.AccountName); ok {
+			idxs = append(idxs, i)
 		}
-		// Enabled (nil) + Include = only the scanned instances are matched.
-		return config.ResourceFilter{Include: ids}
-	}
-	return &config.Config{
-		Servers:               disabled("Server"),
-		BlockStorages:         disabled("Block Storage"),
-		BlockStorageSnapshots: disabled("Block Storage Snapshot"),
-		PublicIps:             disabled("Public IP"),
-		NasVolumes:            disabled("NAS Volume"),
-		NasVolumeSnapshots:    disabled("NAS Volume Snapshot"),
-		LoadBalancers:         disabled("Load Balancer"),
-		TargetGroups:          disabled("Target Group"),
-		CloudDBs:              disabled("Cloud DB"),
-		CloudPostgresqls:      disabled("Cloud PostgreSQL"),
-		CloudMongoDBs:         disabled("Cloud MongoDB"),
-		CloudMariaDBs:         disabled("Cloud MariaDB"),
-		CloudMySQLs:           disabled("Cloud MySQL"),
-		CloudRedises:          disabled("Cloud Redis"),
-		Vpcs:                  disabled("VPC"),
-		Subnets:               disabled("Subnet"),
-		NatGateways:           disabled("NAT Gateway"),
-		VpcPeerings:           disabled("VPC Peering"),
-		NetworkAcls:           disabled("Network ACL"),
-		AccessControlGroups:   disabled("Access Control Group"),
-		AutoScalingGroups:     disabled("Auto Scaling Group"),
-		LaunchConfigurations:  disabled("Launch Configuration"),
-		NksClusters:           disabled("NKS Cluster"),
-		InitScripts:           disabled("Init Script"),
-		LoginKeys:             disabled("Login Key"),
-		PlacementGroups:       disabled("Placement Group"),
-		Buckets:               disabled("Object Storage Bucket"),
-		ApiGatewayProducts:    disabled("API Gateway Product"),
 	}
+	return idxs
 }
 
-type progressEvent struct {
-	Type     string `json:"type"` // account | global | resource
-	Account  string `json:"account"`
-	Text     string `json:"text"`
-	Resource string `json:"resource"` // section title for type=resource
-	Depth    int    `json:"depth"`
-	Status   string `json:"status"` // ok | fail | skip | info
+type planResponse struct {
+	scanResponse
+	Selected  []int     `json:"selected"`
+	CreatedAt time.Time `json:"createdAt"`
+	Total     int       `json:"total"`
 }
 
-func classifyLine(line string, currentResource *string) progressEvent {
-	trimmedLeft := strings.TrimLeft(line, "\n")
-	leading := len(trimmedLeft) - len(strings.TrimLeft(trimmedLeft, " "))
-	depth := leading / 2
-	text := strings.TrimSpace(line)
+// handlePlanLoad checks an uploaded plan file and returns its resources in
+// the shape of a scan result, with the plan's accounts as the selection.
+func (s *Server) handlePlanLoad(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "POST only", http.StatusMethodNotAllowed)
+		return
+	}
+	data, err := io.ReadAll(io.LimitReader(r.Body, 32<<20))
+	if err != nil {
+		http.Error(w, "잘못된 요청: "+err.Error(), http.StatusBadRequest)
+		return
+	}
 
-	ev := progressEvent{Text: text, Depth: depth, Status: classifyStatus(text)}
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	if strings.HasPrefix(text, "[루트 계정") {
-		ev.Type = "account"
-		*currentResource = ""
-		return ev
+	p, err := s.checkPlan(data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
-
-	if res := detectResource(text); res != "" {
-		*currentResource = res
-		ev.Type = "resource"
-		ev.Resource = res
-		return ev
+	resp := planResponse{Selected: s.planSelection(p), CreatedAt: p.CreatedAt, Total: p.Count()}
+	resp.Details = map[string][]itemDTO{}
+	counts := map[string]int{}
+	var order []string
+	for _, a := range p.Accounts {
+		resp.Accounts = append(resp.Accounts, a.Name)
+		for _, res := range a.Resources {
+			rt, _ := ncp.LookupResourceType(res.Type)
+			if counts[rt.Name()] == 0 {
+				order = append(order, rt.Name())
+			}
+			counts[rt.Name()]++
+			resp.Details[rt.Name()] = append(resp.Details[rt.Name()], itemDTO{Account: a.Name, Region: res.Region, Name: res.Name, ID: res.ID})
+		}
+	}
+	for _, k := range order {
+		resp.Types = append(resp.Types, resourceCountDTO{Key: k, Count: counts[k]})
 	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
 
-	// No resource keyword: a deeper-indented line belongs to the current resource;
-	// a top-level phase line (e.g. "리소스 조회 중", "총 N개 ... 시작") is global.
-	if depth >= 2 && *currentResource != "" {
-		ev.Type = "resource"
-		ev.Resource = *currentResource
-		return ev
+// buildDeleteConfig returns a config that deletes ONLY the scanned resources:
+// for each selected type, the filter includes just the given identifiers (id or
+// name); every other type is disabled.
+func buildDeleteConfig(targets map[string][]string) *config.Config {
+	cfg := &config.Config{}
+	for _, rt := range ncp.ResourceTypes() {
+		ids, ok := targets[rt.Name()]
+		if !ok {
+			off := false
+			cfg.SetFilter(rt.Key(), config.ResourceFilter{Enabled: &off})
+			continue
+		}
+		// Enabled (nil) + Include = only the scanned instances are matched,
+		// taken literally even if a name looks like a pattern.
+		include := make([]string, len(ids))
+		for i, id := range ids {
+			include[i] = config.Literal(id)
+		}
+		cfg.SetFilter(rt.Key(), config.ResourceFilter{Include: include})
 	}
+	return cfg
+}
 
-	ev.Type = "global"
-	*currentResource = ""
-	return ev
+// progressEvent is a progress.Event as sent to the UI, with the layout it
+// is rendered in.
+type progressEvent struct {
+	progress.Event
+	Type     string `json:"type"`     // account | region | global | resource
+	Resource string `json:"resource"` // section title for type=resource
 }
 
-// detectResource maps a log line to a resource category by keyword. Order
-// matters: more specific keywords are checked before broader ones.
-func detectResource(t string) string {
+// newProgressEvent lays out e: account and region headers, a section per
+// resource type (and one for sub accounts), and global lines for the rest.
+func newProgressEvent(e progress.Event) progressEvent {
+	ev := progressEvent{Event: e, Type: "global"}
 	switch {
-	case strings.Contains(t, "API Gateway"):
-		return "API Gateway Product"
-	case strings.Contains(t, "Object Storage") || strings.Contains(t, "버킷"):
-		return "Object Storage Bucket"
-	case strings.Contains(t, "NKS"):
-		return "NKS Cluster"
-	case strings.Contains(t, "Auto Scaling"):
-		return "Auto Scaling Group"
-	case strings.Contains(t, "Launch Configuration"):
-		return "Launch Configuration"
-	case strings.Contains(t, "PostgreSQL") || strings.Contains(t, "(Pg)"):
-		return "Cloud PostgreSQL"
-	case strings.Contains(t, "MongoDB") || strings.Contains(t, "(Mongo)"):
-		return "Cloud MongoDB"
-	case strings.Contains(t, "MariaDB"):
-		return "Cloud MariaDB"
-	case strings.Contains(t, "MySQL"):
-		return "Cloud MySQL"
-	case strings.Contains(t, "Redis"):
-		return "Cloud Redis"
-	case strings.Contains(t, "Cloud DB"):
-		return "Cloud DB"
-	case strings.Contains(t, "Load Balancer") || strings.Contains(t, "로드밸런서"):
-		return "Load Balancer"
-	case strings.Contains(t, "Target Group"):
-		return "Target Group"
-	case strings.Contains(t, "NAS 스냅샷") || strings.Contains(t, "NAS Volume Snapshot"):
-		return "NAS Volume Snapshot"
-	case strings.Contains(t, "NAS"):
-		return "NAS Volume"
-	case strings.Contains(t, "Block Storage") || strings.Contains(t, "블록 스토리지") || strings.Contains(t, "스토리지"):
-		return "Block Storage"
-	case strings.Contains(t, "서버"):
-		return "Server"
-	case strings.Contains(t, "Route Table") || strings.Contains(t, "경로"):
-		return "Route Table"
-	case strings.Contains(t, "VPC Peering"):
-		return "VPC Peering"
-	case strings.Contains(t, "NAT Gateway"):
-		return "NAT Gateway"
-	case strings.Contains(t, "공인 IP") || strings.Contains(t, "Public IP"):
-		return "Public IP"
-	case strings.Contains(t, "ACG") || strings.Contains(t, "Access Control"):
-		return "Access Control Group"
-	case strings.Contains(t, "Network ACL"):
-		return "Network ACL"
-	case strings.Contains(t, "Subnet"):
-		return "Subnet"
-	case strings.Contains(t, "VPC"):
-		return "VPC"
-	case strings.Contains(t, "Init Script"):
-		return "Init Script"
-	case strings.Contains(t, "Login Key"):
-		return "Login Key"
-	case strings.Contains(t, "Placement Group"):
-		return "Placement Group"
-	case strings.Contains(t, "서브 계정") || strings.Contains(t, "서브계정"):
-		return "Sub Account"
-	}
-	return ""
-}
-
-func classifyStatus(t string) string {
-	switch {
-	case strings.Contains(t, "[실패]") || strings.Contains(t, "[오류]"):
-		return "fail"
-	case strings.Contains(t, "[성공]"):
-		return "ok"
-	case strings.Contains(t, "[건너뜀]") || strings.Contains(t, "[경고]") || strings.Contains(t, "[대기]"):
-		return "skip"
-	default:
-		return "info"
+	case e.Phase == progress.PhaseAccount:
+		ev.Type = "account"
+	case e.Phase == progress.PhaseRegion:
+		ev.Type = "region"
+	case e.Type == progress.SubAccountType:
+		ev.Type, ev.Resource = "resource", "Sub Account"
+	case e.Type != "":
+		if rt, ok := ncp.LookupResourceType(e.Type); ok {
+			ev.Type, ev.Resource = "resource", rt.Name()
+		}
 	}
+	return ev
 }
 
 func maskKey(k string) string {
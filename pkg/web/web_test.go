@@ -0,0 +1,153 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"ncp-nuke/pkg/config"
+	"ncp-nuke/pkg/ncp"
+	"ncp-nuke/pkg/ncp/ncptest"
+)
+
+// testServer returns a web server with one account on a fake NCP server.
+func testServer(t *testing.T) (*Server, *ncptest.Server, *ncptest.Account) {
+	t.Helper()
+	srv := ncptest.NewServer()
+	t.Cleanup(srv.Close)
+	ak := "AK-" + t.Name()
+	acct := srv.AddAccount(ak, "SK")
+	cfg := &config.Config{
+		Endpoints:  map[string]string{},
+		API:        config.APIConfig{RateLimit: 1000, RateBurst: 1000},
+		JournalDir: t.TempDir(),
+	}
+	for svc, u := range srv.Endpoints() {
+		cfg.Endpoints[string(svc)] = u
+	}
+	s := &Server{cfg: cfg, accounts: []ncp.RootAccount{{AccountName: "Student-01", AccessKey: ak, SecretKey: "SK"}}}
+	return s, srv, acct
+}
+
+// post sends body as JSON to the server's path and returns the response.
+func post(t *testing.T, s *Server, path string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+	data, err := json.Marshal(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, strings.NewReader(string(data))))
+	return rec
+}
+
+func created(age time.Duration) string {
+	return time.Now().Add(-age).Format("2006-01-02T15:04:05-0700")
+}
+
+func TestScanAgeLimits(t *testing.T) {
+	s, _, acct := testServer(t)
+	s.cfg.OlderThan = config.Duration(24 * time.Hour)
+	acct.Seed(ncptest.LoginKeys,
+		ncp.LoginKey{KeyName: "old", CreateDate: created(48 * time.Hour)},
+		ncp.LoginKey{KeyName: "young", CreateDate: created(time.Hour)},
+	)
+
+	rec := post(t, s, "/api/scan", scanRequest{Selected: []int{0}})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("scan: %d %s", rec.Code, rec.Body)
+	}
+	var resp scanResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	excluded := map[string]string{}
+	for _, items := range resp.Details {
+		for _, it := range items {
+			excluded[it.Name] = it.Excluded
+		}
+	}
+	if excluded["old"] != "" || excluded["young"] != "older_than: 1d" {
+		t.Errorf("excluded = %v, want only the young key, by older_than", excluded)
+	}
+}
+
+func TestExecuteAgeLimits(t *testing.T) {
+	s, _, acct := testServer(t)
+	s.cfg.OlderThan = config.Duration(24 * time.Hour)
+	acct.Seed(ncptest.LoginKeys,
+		ncp.LoginKey{KeyName: "old", CreateDate: created(48 * time.Hour)},
+		ncp.LoginKey{KeyName: "young", CreateDate: created(time.Hour)},
+	)
+	keys, _ := ncp.LookupResourceType("login_keys")
+
+	// Even when sent as a target, the young key stays.
+	rec := post(t, s, "/api/execute", executeRequest{
+		Selected: []int{0},
+		Targets:  map[string][]string{keys.Name(): {"old", "young"}},
+		Confirm:  confirmPhrase,
+	})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("execute: %d %s", rec.Code, rec.Body)
+	}
+	if got := strings.Join(acct.IDs(ncptest.LoginKeys), ","); got != "young" {
+		t.Errorf("login keys left: %s, want young", got)
+	}
+}
+func TestExecuteAccountGuard(t *testing.T) {
+	s, _, acct := testServer(t)
+	acct.SetMemberNo("12345")
+	acct.Seed(ncptest.LoginKeys, ncp.LoginKey{KeyName: "key"})
+	s.cfg.AccountGuard = &config.AccountGuard{Deny: []string{"12345"}}
+	keys, _ := ncp.LookupResourceType("login_keys")
+
+	rec := post(t, s, "/api/execute", executeRequest{
+		Selected: []int{0},
+		Targets:  map[string][]string{keys.Name(): {"key"}},
+		Confirm:  confirmPhrase,
+	})
+	if !strings.Contains(rec.Body.String(), "[차단]") {
+		t.Errorf("response does not report the blocked account:\n%s", rec.Body)
+	}
+	if acct.Count(ncptest.LoginKeys) != 1 {
+		t.Error("a denied account was cleaned")
+	}
+}
+
+func TestExecuteRequiresConfirm(t *testing.T) {
+	s, _, acct := testServer(t)
+	acct.Seed(ncptest.LoginKeys, ncp.LoginKey{KeyName: "key"})
+	keys, _ := ncp.LookupResourceType("login_keys")
+
+	rec := post(t, s, "/api/execute", executeRequest{Selected: []int{0}, Targets: map[string][]string{keys.Name(): {"key"}}, Confirm: "yes"})
+	if rec.Code != http.StatusBadRequest || acct.Count(ncptest.LoginKeys) != 1 {
+		t.Errorf("wrong confirm phrase: status %d, %d keys left, want 400 and 1", rec.Code, acct.Count(ncptest.LoginKeys))
+	}
+}
+
+func TestAccountsRetryFailedIdentity(t *testing.T) {
+	s, srv, acct := testServer(t)
+	acct.SetMemberNo("12345")
+	s.cfg.AccountGuard = &config.AccountGuard{Allow: []string{"12345"}}
+	s.cfg.API.MaxAttempts = 1
+
+	list := func() accountDTO {
+		rec := httptest.NewRecorder()
+		s.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/accounts", nil))
+		var out []accountDTO
+		if err := json.NewDecoder(rec.Body).Decode(&out); err != nil || len(out) != 1 {
+			t.Fatalf("accounts: %v, %s", err, rec.Body)
+		}
+		return out[0]
+	}
+	srv.FailNext(1, 503)
+	if a := list(); a.MemberNo != "" || a.Blocked == "" {
+		t.Errorf("failed lookup: %+v, want blocked without a member number", a)
+	}
+	if a := list(); a.MemberNo != "12345" || a.Blocked != "" {
+		t.Errorf("second lookup: %+v, want member 12345 allowed", a)
+	}
+}